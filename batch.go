@@ -0,0 +1,271 @@
+package pango
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/inwinstack/pango/util"
+	"github.com/inwinstack/pango/version"
+)
+
+// Batch collects config changes to be sent to PAN-OS as a single
+// "multi-config" API call, which PAN-OS applies all together or not at all.
+//
+// Changes can be added directly with Set(), Edit(), Delete(), and Move(), or
+// made through any namespace of a client returned from WithBatch():
+//
+//      b := fw.NewBatch()
+//      tx := fw.WithBatch(b)
+//      tx.Objects.Address.Set("vsys1", addr.Entry{...})
+//      tx.Policies.Security.Set("vsys1", security.Entry{...})
+//      results, err := b.Send()
+//
+// The multi-config API call requires PAN-OS 9.0 or later.
+type Batch struct {
+	con *Client
+
+	mu  sync.Mutex
+	ops []batchOp
+}
+
+// BatchResult is the result of a single operation in a batch.
+type BatchResult struct {
+	Id     int
+	Action string
+	Xpath  string
+	Status string
+	Code   int
+	Msg    string
+	Error  error
+}
+
+// NewBatch returns an empty batch that will be sent using this client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{con: c}
+}
+
+// WithBatch returns a shallow copy of the client whose Set, Edit, Delete, and
+// Move calls are added to the given batch instead of being sent to PAN-OS.
+// These calls always succeed, returning a nil response.  All other calls,
+// such as Get, Show, and Op, are still sent to PAN-OS immediately.
+func (c *Client) WithBatch(b *Batch) *Client {
+	ans := *c
+	ans.batch = b
+	return &ans
+}
+
+// Len returns the number of operations in the batch.
+func (b *Batch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.ops)
+}
+
+// Set adds a "set" operation to the batch.
+//
+// The path param should be either a string or a slice of strings.
+//
+// The element param can be either a string of properly formatted XML to send
+// or a struct which can be marshaled into a string.
+func (b *Batch) Set(path, element interface{}) error {
+	return b.add("set", path, element, "", "")
+}
+
+// Edit adds an "edit" operation to the batch.
+//
+// The path param should be either a string or a slice of strings.
+//
+// The element param can be either a string of properly formatted XML to send
+// or a struct which can be marshaled into a string.
+func (b *Batch) Edit(path, element interface{}) error {
+	return b.add("edit", path, element, "", "")
+}
+
+// Delete adds a "delete" operation to the batch.
+//
+// The path param should be either a string or a slice of strings.
+func (b *Batch) Delete(path interface{}) error {
+	return b.add("delete", path, nil, "", "")
+}
+
+// Move adds a "move" operation to the batch.
+//
+// The path param should be either a string or a slice of strings.
+func (b *Batch) Move(path interface{}, where, dst string) error {
+	return b.add("move", path, nil, where, dst)
+}
+
+// Send sends all operations in the batch to PAN-OS as a single multi-config
+// API call.  The batch is emptied if it was sent, whether or not PAN-OS
+// accepted the changes.
+//
+// The result of each operation is returned in the order the operations were
+// added, along with any error encountered.  PAN-OS either applies all of the
+// operations or none of them.
+func (b *Batch) Send() ([]BatchResult, error) {
+	return b.SendContext(b.con.Context())
+}
+
+// SendContext is Send, but bound to the given context.
+func (b *Batch) SendContext(ctx context.Context) ([]BatchResult, error) {
+	b.mu.Lock()
+	ops := b.ops
+	b.mu.Unlock()
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	v := b.con.Versioning()
+	if v != (version.Number{}) && !v.Gte(version.Number{9, 0, 0, ""}) {
+		return nil, fmt.Errorf("multi-config requires PAN-OS 9.0+: %w", util.ErrUnsupportedVersion)
+	}
+
+	req := multiConfig{Ops: make([]multiConfigOp, 0, len(ops))}
+	for i := range ops {
+		req.Ops = append(req.Ops, multiConfigOp{
+			XMLName: xml.Name{Local: ops[i].Action},
+			Id:      strconv.Itoa(i + 1),
+			Xpath:   ops[i].Xpath,
+			Where:   ops[i].Where,
+			Dst:     ops[i].Dst,
+			Element: ops[i].Element,
+		})
+	}
+
+	b.con.LogAction("(multi-config) sending %d operations", len(ops))
+	data := url.Values{}
+	data.Set("type", "config")
+	data.Set("action", "multi-config")
+	if err := addToData("element", req, true, &data); err != nil {
+		return nil, err
+	}
+	if b.con.Target != "" {
+		data.Set("target", b.con.Target)
+	}
+
+	body, err := b.con.CommunicateContext(ctx, data, nil)
+	if body == nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.ops = b.ops[len(ops):]
+	b.mu.Unlock()
+
+	ans := make([]BatchResult, len(ops))
+	for i := range ops {
+		ans[i] = BatchResult{Id: i + 1, Action: ops[i].Action, Xpath: ops[i].Xpath}
+	}
+
+	resp := multiConfigResponse{}
+	if e2 := xml.Unmarshal(body, &resp); e2 != nil {
+		if err == nil {
+			err = fmt.Errorf("Error parsing multi-config response: %s", e2)
+		}
+		return ans, err
+	}
+
+	// Prefer the error of the first failed operation over the overall error.
+	var opErr error
+	for _, r := range resp.Results {
+		id, e2 := strconv.Atoi(r.Id)
+		if e2 != nil || id < 1 || id > len(ans) {
+			continue
+		}
+		res := &ans[id-1]
+		res.Status = r.ResponseStatus
+		res.Code = r.ResponseCode
+		res.Msg = r.message()
+		if r.Failed() {
+			msg := res.Msg
+			if msg == "" {
+				msg = r.codeError()
+			}
+			res.Error = PanosError{Msg: msg, Code: r.ResponseCode, Details: r.Msg.Lines}
+			if opErr == nil {
+				opErr = res.Error
+			}
+		}
+	}
+
+	if opErr != nil {
+		return ans, opErr
+	}
+	return ans, err
+}
+
+func (b *Batch) add(action string, path, element interface{}, where, dst string) error {
+	op := batchOp{
+		Action: action,
+		Xpath:  util.AsXpath(path),
+		Where:  where,
+		Dst:    dst,
+	}
+
+	if element != nil {
+		s, err := asString(element, true)
+		if err != nil {
+			return err
+		}
+		op.Element = s
+	}
+
+	b.mu.Lock()
+	b.ops = append(b.ops, op)
+	b.mu.Unlock()
+
+	return nil
+}
+
+/** Internal structs **/
+
+type batchOp struct {
+	Action  string
+	Xpath   string
+	Where   string
+	Dst     string
+	Element string
+}
+
+type multiConfig struct {
+	XMLName xml.Name `xml:"multi-configuration"`
+	Ops     []multiConfigOp
+}
+
+type multiConfigOp struct {
+	XMLName xml.Name
+	Id      string `xml:"id,attr"`
+	Xpath   string `xml:"xpath,attr"`
+	Where   string `xml:"where,attr,omitempty"`
+	Dst     string `xml:"dst,attr,omitempty"`
+	Element string `xml:",innerxml"`
+}
+
+type multiConfigResponse struct {
+	XMLName xml.Name              `xml:"response"`
+	Results []multiConfigOpResult `xml:"response"`
+}
+
+type multiConfigOpResult struct {
+	Id string `xml:"id,attr"`
+	panosStatus
+	Msg multiConfigMsg `xml:"msg"`
+}
+
+type multiConfigMsg struct {
+	Text  string   `xml:",chardata"`
+	Lines []string `xml:"line"`
+}
+
+func (o multiConfigOpResult) message() string {
+	if len(o.Msg.Lines) > 0 {
+		return panosErrorResponseWithLine{ResponseMsg: o.Msg.Lines}.Error()
+	}
+	return strings.TrimSpace(o.Msg.Text)
+}
@@ -0,0 +1,139 @@
+package pango
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inwinstack/pango/objs/addr"
+	"github.com/inwinstack/pango/updates"
+	"github.com/inwinstack/pango/version"
+)
+
+func TestBatchSend(t *testing.T) {
+	c := &Client{Logging: LogQuiet, Version: version.Number{9, 0, 0, ""}}
+	c.setResponses([][]byte{[]byte(`<response status="success" code="20"><response id="1" status="success" code="20"><msg>command succeeded</msg></response><response id="2" status="success" code="20"><msg>command succeeded</msg></response></response>`)})
+
+	b := c.NewBatch()
+	b.Set("/config/shared/address", "<entry name='one'/>")
+	b.Move("/config/shared/rulebase/security/rules/entry[@name='a']", "after", "b")
+	if b.Len() != 2 {
+		t.Fatalf("Expected 2 ops, got %d", b.Len())
+	}
+
+	results, err := b.Send()
+	if err != nil {
+		t.Fatalf("Error in send: %s", err)
+	} else if b.Len() != 0 {
+		t.Errorf("Batch was not emptied")
+	}
+
+	if len(c.rp) != 1 {
+		t.Fatalf("Expected 1 API call, got %d", len(c.rp))
+	} else if c.rp[0].Get("action") != "multi-config" {
+		t.Errorf("Unexpected action: %s", c.rp[0].Get("action"))
+	}
+	elm := c.rp[0].Get("element")
+	for _, s := range []string{
+		`<multi-configuration>`,
+		`<set id="1" xpath="/config/shared/address"><entry name='one'/></set>`,
+		`<move id="2" xpath="/config/shared/rulebase/security/rules/entry[@name=&#39;a&#39;]" where="after" dst="b"></move>`,
+	} {
+		if !strings.Contains(elm, s) {
+			t.Errorf("Element %q does not contain %q", elm, s)
+		}
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Id != i+1 || r.Status != "success" || r.Error != nil {
+			t.Errorf("Unexpected result: %#v", r)
+		}
+	}
+}
+
+func TestBatchSendFailure(t *testing.T) {
+	c := &Client{Logging: LogQuiet, Version: version.Number{9, 0, 0, ""}}
+	c.setResponses([][]byte{[]byte(`<response status="error" code="12"><response id="1" status="success" code="20"><msg>command succeeded</msg></response><response id="2" status="error" code="12"><msg><line>address -&gt; two is invalid</line></msg></response></response>`)})
+
+	b := c.NewBatch()
+	b.Set("/config/shared/address", "<entry name='one'/>")
+	b.Set("/config/shared/address", "<entry name='two'/>")
+
+	results, err := b.Send()
+	if err == nil {
+		t.Fatalf("Expected an error")
+	} else if err.Error() != "address -> two is invalid" {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	} else if results[0].Error != nil {
+		t.Errorf("First op failed: %s", results[0].Error)
+	} else if e, ok := results[1].Error.(PanosError); !ok || e.Code != 12 {
+		t.Errorf("Unexpected second op error: %#v", results[1].Error)
+	}
+}
+
+func TestBatchUnsupportedVersion(t *testing.T) {
+	c := &Client{Logging: LogQuiet, Version: version.Number{8, 1, 0, ""}}
+	c.setResponses([][]byte{[]byte(`<response status="success" code="20"/>`)})
+
+	b := c.NewBatch()
+	b.Delete("/config/shared/address/entry[@name='one']")
+	if _, err := b.Send(); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected unsupported version, got %v", err)
+	} else if len(c.rp) != 0 {
+		t.Errorf("API call was made")
+	}
+}
+
+func TestFirewallWithBatch(t *testing.T) {
+	fw := &Firewall{Client: Client{Logging: LogQuiet, Version: version.Number{9, 0, 0, ""}}}
+	fw.setResponses([][]byte{[]byte(`<response status="success" code="20"/>`)})
+	fw.Initialize()
+
+	b := fw.NewBatch()
+	tx := fw.WithBatch(b)
+	if err := tx.Objects.Address.Set("vsys1", addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	} else if err = tx.Objects.Address.Delete("vsys1", "two"); err != nil {
+		t.Fatalf("Error in delete: %s", err)
+	}
+
+	if len(fw.rp) != 0 {
+		t.Errorf("Batched calls were sent immediately")
+	} else if b.Len() != 2 {
+		t.Errorf("Expected 2 ops, got %d", b.Len())
+	}
+}
+
+func TestWithContextKeepsUpdatesSettings(t *testing.T) {
+	fw := &Firewall{Client: Client{Logging: LogQuiet}}
+	fw.setResponses([][]byte{[]byte(`<response status="success"><result><system><sw-version>9.0.0</sw-version></system></result></response>`)})
+	fw.Initialize()
+	fw.Updates.RebootPollInterval = time.Second
+	fw.Updates.RebootTimeout = time.Minute
+
+	pano := &Panorama{Client: Client{Logging: LogQuiet}}
+	pano.setResponses([][]byte{[]byte(`<response status="success"><result><system><sw-version>9.0.0</sw-version></system></result></response>`)})
+	pano.Initialize()
+	pano.Updates.RebootPollInterval = time.Second
+	pano.Updates.RebootTimeout = time.Minute
+
+	for _, u := range []*updates.Updates{
+		fw.WithContext(context.Background()).Updates,
+		fw.WithBatch(fw.NewBatch()).Updates,
+		pano.WithContext(context.Background()).Updates,
+		pano.WithBatch(pano.NewBatch()).Updates,
+	} {
+		if u.RebootPollInterval != time.Second || u.RebootTimeout != time.Minute {
+			t.Errorf("Reboot settings were not kept: %s / %s", u.RebootPollInterval, u.RebootTimeout)
+		}
+	}
+}
@@ -2,12 +2,13 @@ package pango
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -20,10 +21,11 @@ import (
 
 // These bit flags control what is logged by client connections.  Of the flags
 // available for use, LogSend and LogReceive will log ALL communication between
-// the connection object and the PAN-OS XML API.  The API key being used for
-// communication will be blanked out, but no other sensitive data will be.  As
-// such, those two flags should be considered for debugging only.  To disable
-// all logging, set the logging level as LogQuiet.
+// the connection object and the PAN-OS XML API.  The API key, passwords, and
+// other well known secrets (such as pre-shared keys) are blanked out, but
+// other sensitive data may not be.  As such, those two flags should be
+// considered for debugging only.  To disable all logging, set the logging
+// level as LogQuiet.
 //
 // The bit-wise flags are as follows:
 //
@@ -46,9 +48,21 @@ const (
 	LogReceive
 )
 
+// JobProgressFunc is a callback invoked by WaitForJob after each poll of the
+// given job ID.  The job param contains the percent complete as well as the
+// per device results, if any.
+type JobProgressFunc func(id uint, job util.BasicJob)
+
 // Client is a generic connector struct.  It provides wrapper functions for
 // invoking the various PAN-OS XPath API methods.  After creating the client,
 // invoke Initialize() to prepare it for use.
+//
+// Once initialized, a client may be shared by multiple goroutines, so long as
+// none of them modify its exported fields, or invoke Initialize() or
+// RetrieveApiKey(), as those modify the client.  The fields that control
+// request limits are only read during Initialize().  The JobProgress callback
+// and the Logger may be invoked concurrently if API calls are made
+// concurrently.
 type Client struct {
 	// Connection properties.
 	Hostname string
@@ -60,6 +74,45 @@ type Client struct {
 	Timeout  int
 	Target   string
 
+	// TLS and transport properties.  Certificate verification is disabled
+	// unless VerifyCertificate is true or CaFile is specified.  CaFile is a
+	// PEM bundle of the CAs to trust, ClientCertFile and ClientKeyFile are
+	// the PEM encoded certificate and key to present to PAN-OS, and
+	// ServerName overrides the hostname used for SNI and verification.
+	// ProxyUrl is the HTTP proxy to send API calls through.  WrapTransport,
+	// if set, is given the configured transport and returns the
+	// http.RoundTripper to actually use, such as one that adds tracing.
+	VerifyCertificate bool
+	CaFile            string
+	ClientCertFile    string
+	ClientKeyFile     string
+	ServerName        string
+	ProxyUrl          string
+	WrapTransport     func(http.RoundTripper) http.RoundTripper
+
+	// Job polling properties.  JobPollInterval is the initial delay between
+	// polls of a job's status, which backs off up to JobPollMaxInterval while
+	// the job makes no progress; if zero, jobs are polled every second.
+	// JobTimeout, if non-zero, is the maximum
+	// time to wait for a job to finish.  JobProgress, if set, is invoked with
+	// the job's status after every poll.
+	JobPollInterval    time.Duration
+	JobPollMaxInterval time.Duration
+	JobTimeout         time.Duration
+	JobProgress        JobProgressFunc
+
+	// Retry is the policy used to retry failed API calls.  If nil, failed
+	// API calls are not retried.
+	Retry RetryPolicy
+
+	// Request limits.  MaxConcurrentRequests is the maximum number of API
+	// calls that may be in flight at once.  RequestsPerSecond is the rate
+	// at which API calls may be made, allowing bursts of up to RequestBurst
+	// calls.  A value of 0 means no limit.
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+	RequestBurst          int
+
 	// Variables determined at runtime.
 	Version    version.Number
 	SystemInfo map[string]string
@@ -68,9 +121,16 @@ type Client struct {
 	// Logging level.
 	Logging uint32
 
+	// Logger, if set, receives all log messages as structured records
+	// instead of the standard library's log package.
+	Logger Logger
+
 	// Internal variables.
 	con     *http.Client
 	api_url string
+	ctx     context.Context
+	limits  *limiter
+	batch   *Batch
 
 	// Variables for testing, response bytes and response index.
 	rp []url.Values
@@ -112,6 +172,28 @@ func (c *Client) Plugins() []map[string]string {
 	return c.Plugin
 }
 
+// WithContext returns a shallow copy of the client whose API calls are all
+// bound to the given context.  Cancelling the context or letting its
+// deadline pass aborts any in flight HTTP request as well as any job polling.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	ans := *c
+	ans.ctx = ctx
+	return &ans
+}
+
+// Context returns the context the client's API calls are bound to.  If no
+// context has been set with WithContext, context.Background() is returned.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
 // Initialize does some initial setup of the Client connection, retrieves
 // the API key if it was not already present, then performs "show system
 // info" to get the PAN-OS version.  The full results are saved into the
@@ -121,6 +203,8 @@ func (c *Client) Plugins() []map[string]string {
 //  * Protocol: https
 //  * Port: (unspecified)
 //  * Timeout: 10
+//  * JobPollInterval: 1 second
+//  * JobPollMaxInterval: 10 seconds
 //  * Logging: LogAction | LogUid
 func (c *Client) Initialize() error {
 	if len(c.rb) == 0 {
@@ -268,25 +352,8 @@ func (c *Client) RequestPasswordHash(val string) (string, error) {
 //
 // This function returns the job ID and if any errors were encountered.
 func (c *Client) ValidateConfig(sync bool) (uint, error) {
-	var err error
-
 	c.LogOp("(op) validating config")
-	type op_req struct {
-		XMLName xml.Name `xml:"validate"`
-		Cmd     string   `xml:"full"`
-	}
-	job_ans := util.JobResponse{}
-	_, err = c.Op(op_req{}, "", nil, &job_ans)
-	if err != nil {
-		return 0, err
-	}
-
-	id := job_ans.Id
-	if !sync {
-		return id, nil
-	}
-
-	return id, c.WaitForJob(id, nil)
+	return c.validate(baseValidate{Full: &struct{}{}}, sync)
 }
 
 // RevertToRunningConfig discards any changes made and reverts to the last
@@ -432,33 +499,22 @@ func (c *Client) UnlockCommits(vsys, admin string) error {
 // if an error was encountered or not are returned from this function.  If
 // the job ID returned is 0, then no commit was needed.
 func (c *Client) Commit(desc string, admins []string, dan, pao, force, sync bool) (uint, error) {
-	c.LogAction("(commit) %q", desc)
-
-	req := baseCommit{Description: desc}
-	if len(admins) > 0 || !dan || !pao {
-		req.Partial = &baseCommitPartial{}
-		if !dan {
-			req.Partial.Dan = "excluded"
-		}
-		if !pao {
-			req.Partial.Pao = "excluded"
-		}
-		req.Partial.Admin = util.StrToMem(admins)
-	}
-	if force {
-		req.Force = ""
-	}
-
-	job, _, err := c.CommitConfig(req, "", nil)
-	if err != nil || !sync || job == 0 {
-		return job, err
-	}
-
-	return job, c.WaitForJob(job, nil)
+	return c.SendCommit(CommitRequest{
+		Description:             desc,
+		Admins:                  admins,
+		ExcludeDeviceAndNetwork: !dan,
+		ExcludePolicyAndObjects: !pao,
+		Force:                   force,
+	}, sync)
 }
 
 // WaitForJob polls the device, waiting for the specified job to finish.
 //
+// The device is polled every JobPollInterval, backing off up to
+// JobPollMaxInterval for as long as the job's progress does not change.  If
+// JobTimeout is set and the job has not finished in that time, an error is
+// returned.  If JobProgress is set, it is invoked after every poll.
+//
 // If you want to unmarshal the response into a struct, then pass in a
 // pointer to the struct for the "resp" param.  If you just want to know if
 // the job completed with a status other than "FAIL", you only need to check
@@ -467,11 +523,25 @@ func (c *Client) Commit(desc string, admins []string, dan, pao, force, sync bool
 // In the case that there are multiple errors returned from the job, the first
 // error is returned as the error string, and no unmarshaling is attempted.
 func (c *Client) WaitForJob(id uint, resp interface{}) error {
+	return c.WaitForJobContext(c.Context(), id, resp)
+}
+
+// WaitForJobContext is WaitForJob, but polling stops as soon as the given
+// context is cancelled or its deadline passes, returning the context's error.
+func (c *Client) WaitForJobContext(ctx context.Context, id uint, resp interface{}) error {
 	var err error
 	var prev uint
 	var data []byte
 	dp := false
 	all_ok := true
+	delay := c.jobPollInterval()
+	parent := ctx
+
+	if c.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.JobTimeout)
+		defer cancel()
+	}
 
 	c.LogOp("(op) waiting for job %d", id)
 	type op_req struct {
@@ -486,15 +556,25 @@ func (c *Client) WaitForJob(id uint, resp interface{}) error {
 		// of strings append to each other instead of zeroing out.
 		ans = util.BasicJob{}
 
+		// Stop polling if the caller is no longer interested.
+		if err = ctx.Err(); err != nil {
+			return c.jobWaitError(parent, id, err)
+		}
+
 		// Get current percent complete.
-		data, err = c.Op(req, "", nil, &ans)
+		data, err = c.OpContext(ctx, req, "", nil, &ans)
 		if err != nil {
-			return err
+			return c.jobWaitError(parent, id, err)
+		}
+
+		if c.JobProgress != nil {
+			c.JobProgress(id, ans)
 		}
 
 		// Output percent complete if it's new.
 		if ans.Progress != prev {
 			prev = ans.Progress
+			delay = c.jobPollInterval()
 			c.LogOp("(op) job %d: %d percent complete", id, prev)
 		}
 
@@ -519,6 +599,17 @@ func (c *Client) WaitForJob(id uint, resp interface{}) error {
 				dp = true
 			}
 		}
+
+		// Wait before polling again.
+		select {
+		case <-ctx.Done():
+			return c.jobWaitError(parent, id, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay += delay / 2
+		if c.JobPollMaxInterval > 0 && delay > c.JobPollMaxInterval {
+			delay = c.JobPollMaxInterval
+		}
 	}
 
 	// Check the results for a failed commit.
@@ -542,28 +633,28 @@ func (c *Client) WaitForJob(id uint, resp interface{}) error {
 // LogAction writes a log message for SET/DELETE operations if LogAction is set.
 func (c *Client) LogAction(msg string, i ...interface{}) {
 	if c.Logging&LogAction == LogAction {
-		log.Printf(msg, i...)
+		c.logf("action", msg, i...)
 	}
 }
 
 // LogQuery writes a log message for GET/SHOW operations if LogQuery is set.
 func (c *Client) LogQuery(msg string, i ...interface{}) {
 	if c.Logging&LogQuery == LogQuery {
-		log.Printf(msg, i...)
+		c.logf("query", msg, i...)
 	}
 }
 
 // LogOp writes a log message for OP operations if LogOp is set.
 func (c *Client) LogOp(msg string, i ...interface{}) {
 	if c.Logging&LogOp == LogOp {
-		log.Printf(msg, i...)
+		c.logf("op", msg, i...)
 	}
 }
 
 // LogUid writes a log message for User-Id operations if LogUid is set.
 func (c *Client) LogUid(msg string, i ...interface{}) {
 	if c.Logging&LogUid == LogUid {
-		log.Printf(msg, i...)
+		c.logf("uid", msg, i...)
 	}
 }
 
@@ -580,28 +671,46 @@ func (c *Client) LogUid(msg string, i ...interface{}) {
 // performed.
 //
 // If the API key is set, but not present in the given data, then it is added in.
+//
+// If the client has a Retry policy, failed attempts are retried according to
+// that policy.
 func (c *Client) Communicate(data url.Values, ans interface{}) ([]byte, error) {
+	return c.CommunicateContext(c.Context(), data, ans)
+}
+
+// CommunicateContext is Communicate, but the HTTP request is bound to the
+// given context.
+func (c *Client) CommunicateContext(ctx context.Context, data url.Values, ans interface{}) ([]byte, error) {
 	if c.ApiKey != "" && data.Get("key") == "" {
 		data.Set("key", c.ApiKey)
 	}
 
-	if c.Logging&LogSend == LogSend {
-		old_key := data.Get("key")
-		if old_key != "" {
-			data.Set("key", "########")
+	c.logSend(data)
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		body, err := c.post(ctx, data)
+		if err == nil {
+			body, err = c.endCommunication(body, ans)
 		}
-		log.Printf("Sending data: %#v", data)
-		if old_key != "" {
-			data.Set("key", old_key)
+		c.logCall(data, start, body, err)
+
+		if err == nil || c.Retry == nil || ctx.Err() != nil {
+			return body, err
 		}
-	}
 
-	body, err := c.post(data)
-	if err != nil {
-		return nil, err
-	}
+		delay, ok := c.Retry.Retry(attempt, err)
+		if !ok {
+			return body, err
+		}
 
-	return c.endCommunication(body, ans)
+		c.LogAction("(retry) attempt %d failed: %s; retrying in %s", attempt, err, delay)
+		select {
+		case <-ctx.Done():
+			return body, err
+		case <-time.After(delay):
+		}
+	}
 }
 
 // CommunicateFile does a file upload to PAN-OS.
@@ -625,22 +734,19 @@ func (c *Client) Communicate(data url.Values, ans interface{}) ([]byte, error) {
 //
 // If the API key is set, but not present in the given data, then it is added in.
 func (c *Client) CommunicateFile(content, filename, fp string, data url.Values, ans interface{}) ([]byte, error) {
+	return c.CommunicateFileContext(c.Context(), content, filename, fp, data, ans)
+}
+
+// CommunicateFileContext is CommunicateFile, but the HTTP request is bound to
+// the given context.
+func (c *Client) CommunicateFileContext(ctx context.Context, content, filename, fp string, data url.Values, ans interface{}) ([]byte, error) {
 	var err error
 
 	if c.ApiKey != "" && data.Get("key") == "" {
 		data.Set("key", c.ApiKey)
 	}
 
-	if c.Logging&LogSend == LogSend {
-		old_key := data.Get("key")
-		if old_key != "" {
-			data.Set("key", "########")
-		}
-		log.Printf("Sending data: %#v", data)
-		if old_key != "" {
-			data.Set("key", old_key)
-		}
-	}
+	c.logSend(data)
 
 	buf := bytes.Buffer{}
 	w := multipart.NewWriter(&buf)
@@ -664,20 +770,31 @@ func (c *Client) CommunicateFile(content, filename, fp string, data url.Values,
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Set("Content-Type", w.FormDataContentType())
 
+	if err = c.limits.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.limits.release()
+
+	start := time.Now()
 	res, err := c.con.Do(req)
 	if err != nil {
+		c.logCall(data, start, nil, err)
 		return nil, err
 	}
 
 	defer res.Body.Close()
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
+		c.logCall(data, start, nil, err)
 		return nil, err
 	}
 
-	return c.endCommunication(body, ans)
+	body, err = c.endCommunication(body, ans)
+	c.logCall(data, start, body, err)
+	return body, err
 }
 
 // Op runs an operational or "op" type command.
@@ -696,6 +813,11 @@ func (c *Client) CommunicateFile(content, filename, fp string, data url.Values,
 // Any response received from the server is returned, along with any errors
 // encountered.
 func (c *Client) Op(req interface{}, vsys string, extras, ans interface{}) ([]byte, error) {
+	return c.OpContext(c.Context(), req, vsys, extras, ans)
+}
+
+// OpContext is Op, but bound to the given context.
+func (c *Client) OpContext(ctx context.Context, req interface{}, vsys string, extras, ans interface{}) ([]byte, error) {
 	var err error
 	data := url.Values{}
 	data.Set("type", "op")
@@ -716,7 +838,7 @@ func (c *Client) Op(req interface{}, vsys string, extras, ans interface{}) ([]by
 		return nil, err
 	}
 
-	return c.Communicate(data, ans)
+	return c.CommunicateContext(ctx, data, ans)
 }
 
 // Show runs a "show" type command.
@@ -732,12 +854,17 @@ func (c *Client) Op(req interface{}, vsys string, extras, ans interface{}) ([]by
 // Any response received from the server is returned, along with any errors
 // encountered.
 func (c *Client) Show(path, extras, ans interface{}) ([]byte, error) {
+	return c.ShowContext(c.Context(), path, extras, ans)
+}
+
+// ShowContext is Show, but bound to the given context.
+func (c *Client) ShowContext(ctx context.Context, path, extras, ans interface{}) ([]byte, error) {
 	data := url.Values{}
 	xp := util.AsXpath(path)
 	c.logXpath(xp)
 	data.Set("xpath", xp)
 
-	return c.typeConfig("show", data, extras, ans)
+	return c.typeConfig(ctx, "show", data, extras, ans)
 }
 
 // Get runs a "get" type command.
@@ -753,12 +880,17 @@ func (c *Client) Show(path, extras, ans interface{}) ([]byte, error) {
 // Any response received from the server is returned, along with any errors
 // encountered.
 func (c *Client) Get(path, extras, ans interface{}) ([]byte, error) {
+	return c.GetContext(c.Context(), path, extras, ans)
+}
+
+// GetContext is Get, but bound to the given context.
+func (c *Client) GetContext(ctx context.Context, path, extras, ans interface{}) ([]byte, error) {
 	data := url.Values{}
 	xp := util.AsXpath(path)
 	c.logXpath(xp)
 	data.Set("xpath", xp)
 
-	return c.typeConfig("get", data, extras, ans)
+	return c.typeConfig(ctx, "get", data, extras, ans)
 }
 
 // Delete runs a "delete" type command, removing the supplied xpath and
@@ -775,12 +907,21 @@ func (c *Client) Get(path, extras, ans interface{}) ([]byte, error) {
 // Any response received from the server is returned, along with any errors
 // encountered.
 func (c *Client) Delete(path, extras, ans interface{}) ([]byte, error) {
+	return c.DeleteContext(c.Context(), path, extras, ans)
+}
+
+// DeleteContext is Delete, but bound to the given context.
+func (c *Client) DeleteContext(ctx context.Context, path, extras, ans interface{}) ([]byte, error) {
+	if c.batch != nil {
+		return nil, c.batch.Delete(path)
+	}
+
 	data := url.Values{}
 	xp := util.AsXpath(path)
 	c.logXpath(xp)
 	data.Set("xpath", xp)
 
-	return c.typeConfig("delete", data, extras, ans)
+	return c.typeConfig(ctx, "delete", data, extras, ans)
 }
 
 // Set runs a "set" type command, creating the element at the given xpath.
@@ -799,7 +940,16 @@ func (c *Client) Delete(path, extras, ans interface{}) ([]byte, error) {
 // Any response received from the server is returned, along with any errors
 // encountered.
 func (c *Client) Set(path, element, extras, ans interface{}) ([]byte, error) {
+	return c.SetContext(c.Context(), path, element, extras, ans)
+}
+
+// SetContext is Set, but bound to the given context.
+func (c *Client) SetContext(ctx context.Context, path, element, extras, ans interface{}) ([]byte, error) {
 	var err error
+
+	if c.batch != nil {
+		return nil, c.batch.Set(path, element)
+	}
 	data := url.Values{}
 	xp := util.AsXpath(path)
 	c.logXpath(xp)
@@ -809,7 +959,7 @@ func (c *Client) Set(path, element, extras, ans interface{}) ([]byte, error) {
 		return nil, err
 	}
 
-	return c.typeConfig("set", data, extras, ans)
+	return c.typeConfig(ctx, "set", data, extras, ans)
 }
 
 // Edit runs a "edit" type command, modifying what is at the given xpath
@@ -829,7 +979,16 @@ func (c *Client) Set(path, element, extras, ans interface{}) ([]byte, error) {
 // Any response received from the server is returned, along with any errors
 // encountered.
 func (c *Client) Edit(path, element, extras, ans interface{}) ([]byte, error) {
+	return c.EditContext(c.Context(), path, element, extras, ans)
+}
+
+// EditContext is Edit, but bound to the given context.
+func (c *Client) EditContext(ctx context.Context, path, element, extras, ans interface{}) ([]byte, error) {
 	var err error
+
+	if c.batch != nil {
+		return nil, c.batch.Edit(path, element)
+	}
 	data := url.Values{}
 	xp := util.AsXpath(path)
 	c.logXpath(xp)
@@ -839,11 +998,20 @@ func (c *Client) Edit(path, element, extras, ans interface{}) ([]byte, error) {
 		return nil, err
 	}
 
-	return c.typeConfig("edit", data, extras, ans)
+	return c.typeConfig(ctx, "edit", data, extras, ans)
 }
 
 // Move does a "move" type command.
 func (c *Client) Move(path interface{}, where, dst string, extras, ans interface{}) ([]byte, error) {
+	return c.MoveContext(c.Context(), path, where, dst, extras, ans)
+}
+
+// MoveContext is Move, but bound to the given context.
+func (c *Client) MoveContext(ctx context.Context, path interface{}, where, dst string, extras, ans interface{}) ([]byte, error) {
+	if c.batch != nil {
+		return nil, c.batch.Move(path, where, dst)
+	}
+
 	data := url.Values{}
 	xp := util.AsXpath(path)
 	c.logXpath(xp)
@@ -857,11 +1025,16 @@ func (c *Client) Move(path interface{}, where, dst string, extras, ans interface
 		data.Set("dst", dst)
 	}
 
-	return c.typeConfig("move", data, extras, ans)
+	return c.typeConfig(ctx, "move", data, extras, ans)
 }
 
 // Uid performs User-ID API calls.
 func (c *Client) Uid(cmd interface{}, vsys string, extras, ans interface{}) ([]byte, error) {
+	return c.UidContext(c.Context(), cmd, vsys, extras, ans)
+}
+
+// UidContext is Uid, but bound to the given context.
+func (c *Client) UidContext(ctx context.Context, cmd interface{}, vsys string, extras, ans interface{}) ([]byte, error) {
 	var err error
 	data := url.Values{}
 	data.Set("type", "user-id")
@@ -882,7 +1055,7 @@ func (c *Client) Uid(cmd interface{}, vsys string, extras, ans interface{}) ([]b
 		return nil, err
 	}
 
-	return c.Communicate(data, ans)
+	return c.CommunicateContext(ctx, data, ans)
 }
 
 // Import performs an import type command.
@@ -973,6 +1146,14 @@ func (c *Client) initCon() error {
 	}
 	tout = time.Duration(time.Duration(c.Timeout) * time.Second)
 
+	// Set the job polling intervals.
+	if c.JobPollInterval == 0 {
+		c.JobPollInterval = time.Second
+	}
+	if c.JobPollMaxInterval == 0 {
+		c.JobPollMaxInterval = 10 * time.Second
+	}
+
 	// Set the protocol
 	if c.Protocol == "" {
 		c.Protocol = "https"
@@ -985,9 +1166,18 @@ func (c *Client) initCon() error {
 		return fmt.Errorf("Port %d is out of bounds", c.Port)
 	}
 
+	// Setup the request limits.
+	if c.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("MaxConcurrentRequests must not be negative")
+	} else if c.RequestsPerSecond < 0 {
+		return fmt.Errorf("RequestsPerSecond must not be negative")
+	}
+	c.limits = newLimiter(c.MaxConcurrentRequests, c.RequestsPerSecond, c.RequestBurst)
+
 	// Setup the https client
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	tr, err := c.initTransport()
+	if err != nil {
+		return err
 	}
 	c.con = &http.Client{
 		Transport: tr,
@@ -1004,6 +1194,50 @@ func (c *Client) initCon() error {
 	return nil
 }
 
+func (c *Client) initTransport() (http.RoundTripper, error) {
+	tc := &tls.Config{
+		InsecureSkipVerify: !c.VerifyCertificate && c.CaFile == "",
+		ServerName:         c.ServerName,
+	}
+
+	if c.CaFile != "" {
+		pem, err := ioutil.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA file %q: %s", c.CaFile, err)
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificates found in CA file %q", c.CaFile)
+		}
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading client certificate: %s", err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+
+	tr := &http.Transport{
+		TLSClientConfig: tc,
+	}
+
+	if c.ProxyUrl != "" {
+		u, err := url.Parse(c.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy url %q: %s", c.ProxyUrl, err)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+
+	if c.WrapTransport != nil {
+		return c.WrapTransport(tr), nil
+	}
+
+	return tr, nil
+}
+
 func (c *Client) initApiKey() error {
 	if c.ApiKey != "" {
 		return nil
@@ -1058,7 +1292,7 @@ func (c *Client) initSystemInfo() error {
 	return nil
 }
 
-func (c *Client) typeConfig(action string, data url.Values, extras, ans interface{}) ([]byte, error) {
+func (c *Client) typeConfig(ctx context.Context, action string, data url.Values, extras, ans interface{}) ([]byte, error) {
 	var err error
 
 	data.Set("type", "config")
@@ -1071,12 +1305,29 @@ func (c *Client) typeConfig(action string, data url.Values, extras, ans interfac
 		return nil, err
 	}
 
-	return c.Communicate(data, ans)
+	return c.CommunicateContext(ctx, data, ans)
+}
+
+// jobPollInterval returns JobPollInterval, defaulting to one second if unset.
+func (c *Client) jobPollInterval() time.Duration {
+	if c.JobPollInterval > 0 {
+		return c.JobPollInterval
+	}
+	return time.Second
+}
+
+// jobWaitError makes the error returned when JobTimeout expires more
+// descriptive than context.DeadlineExceeded.
+func (c *Client) jobWaitError(parent context.Context, id uint, err error) error {
+	if err == context.DeadlineExceeded && c.JobTimeout > 0 && parent.Err() == nil {
+		return fmt.Errorf("Job %d did not finish within %s", id, c.JobTimeout)
+	}
+	return err
 }
 
 func (c *Client) logXpath(p string) {
 	if c.Logging&LogXpath == LogXpath {
-		log.Printf("(xpath) %s", p)
+		c.logf("xpath", "(xpath) %s", p)
 	}
 }
 
@@ -1164,15 +1415,35 @@ func (c *Client) xpathImport(tmpl, ts, vsys string) []string {
 	return ans
 }
 
-func (c *Client) post(data url.Values) ([]byte, error) {
+func (c *Client) post(ctx context.Context, data url.Values) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := c.limits.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.limits.release()
+
 	if len(c.rb) == 0 {
-		r, err := c.con.PostForm(c.api_url, data)
+		req, err := http.NewRequest("POST", c.api_url, strings.NewReader(data.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		r, err := c.con.Do(req)
 		if err != nil {
 			return nil, err
 		}
 
 		defer r.Body.Close()
-		return ioutil.ReadAll(r.Body)
+		body, err := ioutil.ReadAll(r.Body)
+		if err == nil && r.StatusCode >= 500 && !bytes.HasPrefix(bytes.TrimSpace(body), []byte("<response")) {
+			err = HttpError{r.StatusCode, r.Status, body}
+		}
+		return body, err
 	} else {
 		if c.ri < len(c.rb) {
 			c.rp = append(c.rp, data)
@@ -1186,9 +1457,7 @@ func (c *Client) post(data url.Values) ([]byte, error) {
 func (c *Client) endCommunication(body []byte, ans interface{}) ([]byte, error) {
 	var err error
 
-	if c.Logging&LogReceive == LogReceive {
-		log.Printf("Response = %s", body)
-	}
+	c.logReceive(body)
 
 	// Check for errors first
 	errType1 := &panosErrorResponseWithoutLine{}
@@ -1199,12 +1468,16 @@ func (c *Client) endCommunication(body []byte, ans interface{}) ([]byte, error)
 	// a result.
 	if errType1.Failed() {
 		if err == nil && errType1.Error() != "" {
-			return body, PanosError{errType1.Error(), errType1.ResponseCode}
+			return body, PanosError{Msg: errType1.Error(), Code: errType1.ResponseCode}
 		}
 		errType2 := panosErrorResponseWithLine{}
 		err = xml.Unmarshal(body, &errType2)
 		if err == nil && errType2.Error() != "" {
-			return body, PanosError{errType2.Error(), errType2.ResponseCode}
+			return body, PanosError{
+				Msg:     errType2.Error(),
+				Code:    errType2.ResponseCode,
+				Details: errType2.ResponseMsg,
+			}
 		}
 		// Still an error, but some unknown format.
 		return body, fmt.Errorf("Unknown error format: %s", body)
@@ -1352,9 +1625,14 @@ func asString(i interface{}, attemptMarshal bool) (string, error) {
 }
 
 // PanosError is the error struct returned from the Communicate method.
+//
+// Code is the response code sent by PAN-OS, and Details are the individual
+// message lines of the response, if any.  Use errors.Is with one of the Err*
+// sentinel errors to check for a specific kind of failure.
 type PanosError struct {
-	Msg  string
-	Code int
+	Msg     string
+	Code    int
+	Details []string
 }
 
 // Error returns the error message.
@@ -1432,13 +1710,14 @@ func (e panosStatus) codeError() string {
 type panosErrorResponseWithLine struct {
 	XMLName xml.Name `xml:"response"`
 	panosStatus
-	ResponseMsg string `xml:"msg>line"`
+	ResponseMsg []string `xml:"msg>line"`
 }
 
-// Error retrieves the parsed error message.
+// Error retrieves the parsed error message, which is the last message line.
+// All lines are available as the Details of the PanosError.
 func (e panosErrorResponseWithLine) Error() string {
-	if e.ResponseMsg != "" {
-		return e.ResponseMsg
+	if len(e.ResponseMsg) > 0 && e.ResponseMsg[len(e.ResponseMsg)-1] != "" {
+		return e.ResponseMsg[len(e.ResponseMsg)-1]
 	} else {
 		return e.codeError()
 	}
@@ -1485,7 +1764,21 @@ type baseCommit struct {
 }
 
 type baseCommitPartial struct {
-	Dan   string           `xml:"device-and-network,omitempty"`
-	Pao   string           `xml:"policy-and-objects,omitempty"`
-	Admin *util.MemberType `xml:"admin"`
+	Dan               string           `xml:"device-and-network,omitempty"`
+	Pao               string           `xml:"policy-and-objects,omitempty"`
+	SharedObject      string           `xml:"shared-object,omitempty"`
+	Admin             *util.MemberType `xml:"admin"`
+	Vsys              *util.MemberType `xml:"vsys"`
+	NoVsys            *struct{}        `xml:"no-vsys"`
+	DeviceGroup       *util.MemberType `xml:"device-group"`
+	Template          *util.MemberType `xml:"template"`
+	TemplateStack     *util.MemberType `xml:"template-stack"`
+	LogCollector      *util.MemberType `xml:"log-collector"`
+	LogCollectorGroup *util.MemberType `xml:"log-collector-group"`
+}
+
+type baseValidate struct {
+	XMLName xml.Name           `xml:"validate"`
+	Full    *struct{}          `xml:"full"`
+	Partial *baseCommitPartial `xml:"partial"`
 }
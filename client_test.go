@@ -2,12 +2,21 @@ package pango
 
 import (
     "bytes"
+    "context"
+    "encoding/pem"
+    "io/ioutil"
     "log"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
     "os"
+    "reflect"
     "strings"
     "testing"
+    "time"
 
     "github.com/inwinstack/pango/testdata"
+    "github.com/inwinstack/pango/util"
 )
 
 
@@ -162,9 +171,9 @@ func TestLogUidEnabled(t *testing.T) {
 
 func TestRetrieveApiKey(t *testing.T) {
     c := &Client{}
-    c.rb = [][]byte{
+    c.setResponses([][]byte{
         []byte(testdata.ApiKeyXml),
-    }
+    })
     if err := c.Initialize(); err != nil {
         t.Errorf("Initialize failed: %s", err)
         return
@@ -177,3 +186,161 @@ func TestRetrieveApiKey(t *testing.T) {
         t.Fail()
     }
 }
+
+func TestCancelledContextAbortsCommunicate(t *testing.T) {
+    c := &Client{}
+    c.setResponses([][]byte{
+        []byte("<response status=\"success\"><result /></response>"),
+    })
+    if err := c.Initialize(); err != nil {
+        t.Errorf("Initialize failed: %s", err)
+        return
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    if _, err := c.OpContext(ctx, "<show><system><info /></system></show>", "", nil, nil); err != context.Canceled {
+        t.Errorf("Expected context.Canceled, got %v", err)
+    }
+    if _, err := c.WithContext(ctx).Get("/config", nil, nil); err != context.Canceled {
+        t.Errorf("Expected context.Canceled from WithContext, got %v", err)
+    }
+    if err := c.WaitForJobContext(ctx, 1, nil); err != context.Canceled {
+        t.Errorf("Expected context.Canceled from job wait, got %v", err)
+    }
+    if len(c.rp) != 0 {
+        t.Errorf("Expected no requests to be sent, got %d", len(c.rp))
+    }
+}
+
+func TestContextDefaultsToBackground(t *testing.T) {
+    c := &Client{}
+    if c.Context() != context.Background() {
+        t.Fail()
+    }
+}
+
+func TestWaitForJobReportsProgress(t *testing.T) {
+    c := &Client{}
+    c.setResponses([][]byte{
+        []byte(`<response status="success"><result><job><progress>40</progress></job></result></response>`),
+        []byte(`<response status="success"><result><job><progress>100</progress><result>OK</result><devices><entry><serial-no>0123</serial-no><result>PEND</result></entry></devices></job></result></response>`),
+        []byte(`<response status="success"><result><job><progress>100</progress><result>OK</result><devices><entry><serial-no>0123</serial-no><result>OK</result></entry></devices></job></result></response>`),
+    })
+    c.JobPollInterval = time.Millisecond
+    if err := c.Initialize(); err != nil {
+        t.Errorf("Initialize failed: %s", err)
+        return
+    }
+
+    var progress []uint
+    var devices int
+    c.JobProgress = func(id uint, job util.BasicJob) {
+        if id != 42 {
+            t.Errorf("Expected job 42, got %d", id)
+        }
+        progress = append(progress, job.Progress)
+        devices += len(job.Devices)
+    }
+
+    if err := c.WaitForJob(42, nil); err != nil {
+        t.Errorf("Error waiting for job: %s", err)
+    } else if !reflect.DeepEqual(progress, []uint{40, 100, 100}) {
+        t.Errorf("Unexpected progress reported: %v", progress)
+    } else if devices != 2 {
+        t.Errorf("Expected 2 device results, got %d", devices)
+    }
+}
+
+func TestWaitForJobTimeout(t *testing.T) {
+    c := &Client{}
+    c.setResponses([][]byte{
+        []byte(`<response status="success"><result><job><progress>10</progress></job></result></response>`),
+    })
+    if err := c.Initialize(); err != nil {
+        t.Errorf("Initialize failed: %s", err)
+        return
+    }
+    c.JobPollInterval = time.Millisecond
+    c.JobTimeout = 20 * time.Millisecond
+
+    err := c.WaitForJob(7, nil)
+    if err == nil || !strings.Contains(err.Error(), "did not finish") {
+        t.Errorf("Expected a timeout error, got %v", err)
+    }
+}
+
+func TestTlsVerificationWithCaFile(t *testing.T) {
+    srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        r.ParseForm()
+        if r.Form.Get("type") == "keygen" {
+            w.Write([]byte(testdata.ApiKeyXml))
+        } else {
+            w.Write([]byte(`<response status="success"><result><system><sw-version>8.1.0</sw-version></system></result></response>`))
+        }
+    }))
+    defer srv.Close()
+
+    f, err := ioutil.TempFile("", "pango-ca")
+    if err != nil {
+        t.Fatalf("Failed to create CA file: %s", err)
+    }
+    defer os.Remove(f.Name())
+    pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+    f.Close()
+
+    var calls int
+    u, _ := url.Parse(srv.URL)
+    c := &Client{
+        Hostname: u.Host,
+        Username: "admin",
+        Password: "admin",
+        Logging: LogQuiet,
+        CaFile: f.Name(),
+        ServerName: "example.com",
+        WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+            return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+                calls++
+                return rt.RoundTrip(r)
+            })
+        },
+    }
+
+    if err = c.Initialize(); err != nil {
+        t.Errorf("Initialize failed: %s", err)
+    } else if c.ApiKey != "secret" || c.Version.String() != "8.1.0" {
+        t.Errorf("Unexpected client state: %s %s", c.ApiKey, c.Version)
+    } else if calls != 2 {
+        t.Errorf("Expected 2 calls through the wrapped transport, got %d", calls)
+    }
+
+    c2 := &Client{
+        Hostname: u.Host,
+        ApiKey: "secret",
+        Logging: LogQuiet,
+        VerifyCertificate: true,
+    }
+    if err = c2.Initialize(); err == nil {
+        t.Errorf("Self-signed certificate was not rejected")
+    }
+}
+
+func TestInvalidCaFile(t *testing.T) {
+    c := &Client{Hostname: "localhost", CaFile: "/nonexistent/ca.pem"}
+    if err := c.Initialize(); err == nil {
+        t.Fail()
+    }
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+    return f(r)
+}
+
+// setResponses puts the client into testing mode, answering API calls with
+// the given responses.
+func (c *Client) setResponses(rb [][]byte) {
+    c.rb = rb
+}
@@ -0,0 +1,129 @@
+package pango
+
+import (
+	"github.com/inwinstack/pango/util"
+)
+
+// CommitRequest describes a commit, or a commit validation, along with
+// its partial commit scope.
+//
+// Leaving all scope fields unset performs a full commit.  Setting any of them
+// makes this a partial commit limited to the given scope.
+//
+// Vsys and NoVsys are firewall only, while DeviceGroups, Templates,
+// TemplateStacks, LogCollectors, and LogCollectorGroups are Panorama only.
+type CommitRequest struct {
+	// Description is the commit description.  It is ignored for validation.
+	Description string
+
+	// Admins limits the commit to changes made by the given administrators.
+	Admins []string
+
+	ExcludeDeviceAndNetwork bool
+	ExcludeSharedObjects    bool
+	ExcludePolicyAndObjects bool
+
+	// Vsys limits the commit to changes in the given virtual systems.
+	Vsys []string
+
+	// NoVsys includes changes not made to a virtual system.
+	NoVsys bool
+
+	DeviceGroups       []string
+	Templates          []string
+	TemplateStacks     []string
+	LogCollectors      []string
+	LogCollectorGroups []string
+
+	// Force forces a commit even if no changes are required.  It is ignored
+	// for validation.
+	Force bool
+
+	// ValidateOnly validates the candidate config within the scope instead
+	// of committing it, in the same way as ValidateConfig().
+	ValidateOnly bool
+}
+
+// IsPartial returns true if this request is limited to a partial scope.
+func (o CommitRequest) IsPartial() bool {
+	return o.partial() != nil
+}
+
+func (o CommitRequest) partial() *baseCommitPartial {
+	ans := baseCommitPartial{
+		Admin:             util.StrToMem(o.Admins),
+		Vsys:              util.StrToMem(o.Vsys),
+		DeviceGroup:       util.StrToMem(o.DeviceGroups),
+		Template:          util.StrToMem(o.Templates),
+		TemplateStack:     util.StrToMem(o.TemplateStacks),
+		LogCollector:      util.StrToMem(o.LogCollectors),
+		LogCollectorGroup: util.StrToMem(o.LogCollectorGroups),
+	}
+
+	if o.ExcludeDeviceAndNetwork {
+		ans.Dan = "excluded"
+	}
+	if o.ExcludeSharedObjects {
+		ans.SharedObject = "excluded"
+	}
+	if o.ExcludePolicyAndObjects {
+		ans.Pao = "excluded"
+	}
+	if o.NoVsys {
+		ans.NoVsys = &struct{}{}
+	}
+
+	if ans == (baseCommitPartial{}) {
+		return nil
+	}
+	return &ans
+}
+
+// SendCommit performs the given commit, or commit validation, on this
+// PAN-OS device.
+//
+// Param sync should be true if you want this function to block until the
+// job completes.
+//
+// Commits result in a job being submitted to the backend.  The job ID and
+// if an error was encountered or not are returned from this function.  If
+// the job ID returned is 0, then no commit was needed.
+func (c *Client) SendCommit(req CommitRequest, sync bool) (uint, error) {
+	if req.ValidateOnly {
+		c.LogOp("(op) validating config, partial: %t", req.IsPartial())
+		cmd := baseValidate{Partial: req.partial()}
+		if cmd.Partial == nil {
+			cmd.Full = &struct{}{}
+		}
+		return c.validate(cmd, sync)
+	}
+
+	c.LogAction("(commit) %q", req.Description)
+
+	cmd := baseCommit{Description: req.Description, Partial: req.partial()}
+	if req.Force {
+		cmd.Force = ""
+	}
+
+	job, _, err := c.CommitConfig(cmd, "", nil)
+	if err != nil || !sync || job == 0 {
+		return job, err
+	}
+
+	return job, c.WaitForJob(job, nil)
+}
+
+// validate submits a validation job, optionally waiting for it to finish.
+func (c *Client) validate(cmd baseValidate, sync bool) (uint, error) {
+	ans := util.JobResponse{}
+	if _, err := c.Op(cmd, "", nil, &ans); err != nil {
+		return 0, err
+	}
+
+	id := ans.Id
+	if !sync {
+		return id, nil
+	}
+
+	return id, c.WaitForJob(id, nil)
+}
@@ -0,0 +1,83 @@
+package pango
+
+import (
+	"testing"
+)
+
+func TestSendCommitPartial(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{[]byte(`<response status="success" code="19"><result><job>5</job></result></response>`)})
+
+	job, err := c.SendCommit(CommitRequest{
+		Description:          "test",
+		ExcludeSharedObjects: true,
+		Vsys:                 []string{"vsys2"},
+		NoVsys:               true,
+	}, false)
+	if err != nil {
+		t.Fatalf("Error in commit: %s", err)
+	} else if job != 5 {
+		t.Errorf("Expected job 5, got %d", job)
+	}
+
+	expected := `<commit><description>test</description><partial><shared-object>excluded</shared-object><vsys><member>vsys2</member></vsys><no-vsys></no-vsys></partial></commit>`
+	if c.rp[0].Get("type") != "commit" {
+		t.Errorf("Unexpected type: %s", c.rp[0].Get("type"))
+	} else if cmd := c.rp[0].Get("cmd"); cmd != expected {
+		t.Errorf("Expected %s, got %s", expected, cmd)
+	}
+}
+
+func TestSendCommitPanoramaScope(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{[]byte(`<response status="success" code="19"><result><job>5</job></result></response>`)})
+
+	req := CommitRequest{
+		DeviceGroups:       []string{"dg1", "dg2"},
+		Templates:          []string{"t1"},
+		TemplateStacks:     []string{"ts1"},
+		LogCollectors:      []string{"0001"},
+		LogCollectorGroups: []string{"lcg"},
+	}
+	if !req.IsPartial() {
+		t.Errorf("Request is not partial")
+	} else if _, err := c.SendCommit(req, false); err != nil {
+		t.Fatalf("Error in commit: %s", err)
+	}
+
+	expected := `<commit><partial><device-group><member>dg1</member><member>dg2</member></device-group><template><member>t1</member></template><template-stack><member>ts1</member></template-stack><log-collector><member>0001</member></log-collector><log-collector-group><member>lcg</member></log-collector-group></partial></commit>`
+	if cmd := c.rp[0].Get("cmd"); cmd != expected {
+		t.Errorf("Expected %s, got %s", expected, cmd)
+	}
+}
+
+func TestSendCommitValidateOnly(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		req      CommitRequest
+		expected string
+	}{
+		{"full", CommitRequest{ValidateOnly: true, Description: "ignored"}, "<validate><full></full></validate>"},
+		{"partial", CommitRequest{ValidateOnly: true, ExcludeDeviceAndNetwork: true}, "<validate><partial><device-and-network>excluded</device-and-network></partial></validate>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &Client{Logging: LogQuiet}
+			c.setResponses([][]byte{[]byte(`<response status="success" code="19"><result><job>7</job></result></response>`)})
+
+			job, err := c.SendCommit(tc.req, false)
+			if err != nil {
+				t.Fatalf("Error in validate: %s", err)
+			} else if job != 7 {
+				t.Errorf("Expected job 7, got %d", job)
+			}
+
+			if c.rp[0].Get("type") != "op" {
+				t.Errorf("Unexpected type: %s", c.rp[0].Get("type"))
+			} else if cmd := c.rp[0].Get("cmd"); cmd != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, cmd)
+			}
+		})
+	}
+}
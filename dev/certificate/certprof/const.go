@@ -0,0 +1,19 @@
+package certprof
+
+// Valid values for UsernameField.
+const (
+    UsernameFieldSubject = "subject"
+    UsernameFieldSubjectAlt = "subject-alt"
+)
+
+// Valid values for UsernameFieldValue.
+const (
+    UsernameFieldValueCommonName = "common-name"
+    UsernameFieldValueEmail = "email"
+    UsernameFieldValuePrincipalName = "principal-name"
+)
+
+const (
+    singular = "certificate profile"
+    plural = "certificate profiles"
+)
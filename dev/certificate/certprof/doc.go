@@ -0,0 +1,6 @@
+/*
+Package certprof is the client.Device.CertificateProfile namespace.
+
+Normalized object: Entry
+*/
+package certprof
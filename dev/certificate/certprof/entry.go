@@ -0,0 +1,323 @@
+package certprof
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a
+// certificate profile.
+//
+// UsernameField is where the username is taken from: the subject or the
+// subject alternative name.  UsernameFieldValue is the part of that field to
+// use: the common name for the subject, or the email or principal name for
+// the subject alternative name.
+//
+// The timeouts are in seconds.  BlockUnauthenticatedCertificate requires PAN-OS
+// 8.1+, and BlockExpiredCertificate requires PAN-OS 9.0+.
+type Entry struct {
+    Name string
+    UsernameField string
+    UsernameFieldValue string
+    Domain string
+    Certificates []Certificate
+    UseCrl bool
+    UseOcsp bool
+    CrlReceiveTimeout int
+    OcspReceiveTimeout int
+    CertificateStatusTimeout int
+    BlockUnknownCertificate bool
+    BlockCertificateTimeout bool
+    BlockUnauthenticatedCertificate bool
+    BlockExpiredCertificate bool
+}
+
+// Certificate is a CA certificate in the certificate profile.
+type Certificate struct {
+    Name string
+    DefaultOcspUrl string
+    OcspVerifyCertificate string
+    TemplateName string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.UsernameField = s.UsernameField
+    o.UsernameFieldValue = s.UsernameFieldValue
+    o.Domain = s.Domain
+    o.Certificates = s.Certificates
+    o.UseCrl = s.UseCrl
+    o.UseOcsp = s.UseOcsp
+    o.CrlReceiveTimeout = s.CrlReceiveTimeout
+    o.OcspReceiveTimeout = s.OcspReceiveTimeout
+    o.CertificateStatusTimeout = s.CertificateStatusTimeout
+    o.BlockUnknownCertificate = s.BlockUnknownCertificate
+    o.BlockCertificateTimeout = s.BlockCertificateTimeout
+    o.BlockUnauthenticatedCertificate = s.BlockUnauthenticatedCertificate
+    o.BlockExpiredCertificate = s.BlockExpiredCertificate
+}
+
+/** Structs / functions for normalization. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Domain: o.Answer.Domain,
+        UseCrl: util.AsBool(o.Answer.UseCrl),
+        UseOcsp: util.AsBool(o.Answer.UseOcsp),
+        CrlReceiveTimeout: o.Answer.CrlReceiveTimeout,
+        OcspReceiveTimeout: o.Answer.OcspReceiveTimeout,
+        CertificateStatusTimeout: o.Answer.CertificateStatusTimeout,
+        BlockUnknownCertificate: util.AsBool(o.Answer.BlockUnknownCertificate),
+        BlockCertificateTimeout: util.AsBool(o.Answer.BlockCertificateTimeout),
+    }
+
+    ans.UsernameField, ans.UsernameFieldValue = o.Answer.UsernameField.normalize()
+    ans.Certificates = o.Answer.Certificates.normalize()
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    UsernameField *usernameField `xml:"username-field"`
+    Domain string `xml:"domain,omitempty"`
+    Certificates *certs `xml:"CA"`
+    UseCrl string `xml:"use-crl"`
+    UseOcsp string `xml:"use-ocsp"`
+    CrlReceiveTimeout int `xml:"crl-receive-timeout,omitempty"`
+    OcspReceiveTimeout int `xml:"ocsp-receive-timeout,omitempty"`
+    CertificateStatusTimeout int `xml:"cert-status-timeout,omitempty"`
+    BlockUnknownCertificate string `xml:"block-unknown-cert"`
+    BlockCertificateTimeout string `xml:"block-timeout-cert"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        UsernameField: specifyUsernameField(e),
+        Domain: e.Domain,
+        Certificates: specifyCerts(e.Certificates),
+        UseCrl: util.YesNo(e.UseCrl),
+        UseOcsp: util.YesNo(e.UseOcsp),
+        CrlReceiveTimeout: e.CrlReceiveTimeout,
+        OcspReceiveTimeout: e.OcspReceiveTimeout,
+        CertificateStatusTimeout: e.CertificateStatusTimeout,
+        BlockUnknownCertificate: util.YesNo(e.BlockUnknownCertificate),
+        BlockCertificateTimeout: util.YesNo(e.BlockCertificateTimeout),
+    }
+
+    return ans
+}
+
+// PAN-OS 8.1+: block-unauthenticated-cert added.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Domain: o.Answer.Domain,
+        UseCrl: util.AsBool(o.Answer.UseCrl),
+        UseOcsp: util.AsBool(o.Answer.UseOcsp),
+        CrlReceiveTimeout: o.Answer.CrlReceiveTimeout,
+        OcspReceiveTimeout: o.Answer.OcspReceiveTimeout,
+        CertificateStatusTimeout: o.Answer.CertificateStatusTimeout,
+        BlockUnknownCertificate: util.AsBool(o.Answer.BlockUnknownCertificate),
+        BlockCertificateTimeout: util.AsBool(o.Answer.BlockCertificateTimeout),
+        BlockUnauthenticatedCertificate: util.AsBool(o.Answer.BlockUnauthenticatedCertificate),
+    }
+
+    ans.UsernameField, ans.UsernameFieldValue = o.Answer.UsernameField.normalize()
+    ans.Certificates = o.Answer.Certificates.normalize()
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    UsernameField *usernameField `xml:"username-field"`
+    Domain string `xml:"domain,omitempty"`
+    Certificates *certs `xml:"CA"`
+    UseCrl string `xml:"use-crl"`
+    UseOcsp string `xml:"use-ocsp"`
+    CrlReceiveTimeout int `xml:"crl-receive-timeout,omitempty"`
+    OcspReceiveTimeout int `xml:"ocsp-receive-timeout,omitempty"`
+    CertificateStatusTimeout int `xml:"cert-status-timeout,omitempty"`
+    BlockUnknownCertificate string `xml:"block-unknown-cert"`
+    BlockCertificateTimeout string `xml:"block-timeout-cert"`
+    BlockUnauthenticatedCertificate string `xml:"block-unauthenticated-cert"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        UsernameField: specifyUsernameField(e),
+        Domain: e.Domain,
+        Certificates: specifyCerts(e.Certificates),
+        UseCrl: util.YesNo(e.UseCrl),
+        UseOcsp: util.YesNo(e.UseOcsp),
+        CrlReceiveTimeout: e.CrlReceiveTimeout,
+        OcspReceiveTimeout: e.OcspReceiveTimeout,
+        CertificateStatusTimeout: e.CertificateStatusTimeout,
+        BlockUnknownCertificate: util.YesNo(e.BlockUnknownCertificate),
+        BlockCertificateTimeout: util.YesNo(e.BlockCertificateTimeout),
+        BlockUnauthenticatedCertificate: util.YesNo(e.BlockUnauthenticatedCertificate),
+    }
+
+    return ans
+}
+
+// PAN-OS 9.0+: block-expired-cert added.
+type container_v3 struct {
+    Answer entry_v3 `xml:"result>entry"`
+}
+
+func (o *container_v3) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Domain: o.Answer.Domain,
+        UseCrl: util.AsBool(o.Answer.UseCrl),
+        UseOcsp: util.AsBool(o.Answer.UseOcsp),
+        CrlReceiveTimeout: o.Answer.CrlReceiveTimeout,
+        OcspReceiveTimeout: o.Answer.OcspReceiveTimeout,
+        CertificateStatusTimeout: o.Answer.CertificateStatusTimeout,
+        BlockUnknownCertificate: util.AsBool(o.Answer.BlockUnknownCertificate),
+        BlockCertificateTimeout: util.AsBool(o.Answer.BlockCertificateTimeout),
+        BlockUnauthenticatedCertificate: util.AsBool(o.Answer.BlockUnauthenticatedCertificate),
+        BlockExpiredCertificate: util.AsBool(o.Answer.BlockExpiredCertificate),
+    }
+
+    ans.UsernameField, ans.UsernameFieldValue = o.Answer.UsernameField.normalize()
+    ans.Certificates = o.Answer.Certificates.normalize()
+
+    return ans
+}
+
+type entry_v3 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    UsernameField *usernameField `xml:"username-field"`
+    Domain string `xml:"domain,omitempty"`
+    Certificates *certs `xml:"CA"`
+    UseCrl string `xml:"use-crl"`
+    UseOcsp string `xml:"use-ocsp"`
+    CrlReceiveTimeout int `xml:"crl-receive-timeout,omitempty"`
+    OcspReceiveTimeout int `xml:"ocsp-receive-timeout,omitempty"`
+    CertificateStatusTimeout int `xml:"cert-status-timeout,omitempty"`
+    BlockUnknownCertificate string `xml:"block-unknown-cert"`
+    BlockCertificateTimeout string `xml:"block-timeout-cert"`
+    BlockUnauthenticatedCertificate string `xml:"block-unauthenticated-cert"`
+    BlockExpiredCertificate string `xml:"block-expired-cert"`
+}
+
+func specify_v3(e Entry) interface{} {
+    ans := entry_v3{
+        Name: e.Name,
+        UsernameField: specifyUsernameField(e),
+        Domain: e.Domain,
+        Certificates: specifyCerts(e.Certificates),
+        UseCrl: util.YesNo(e.UseCrl),
+        UseOcsp: util.YesNo(e.UseOcsp),
+        CrlReceiveTimeout: e.CrlReceiveTimeout,
+        OcspReceiveTimeout: e.OcspReceiveTimeout,
+        CertificateStatusTimeout: e.CertificateStatusTimeout,
+        BlockUnknownCertificate: util.YesNo(e.BlockUnknownCertificate),
+        BlockCertificateTimeout: util.YesNo(e.BlockCertificateTimeout),
+        BlockUnauthenticatedCertificate: util.YesNo(e.BlockUnauthenticatedCertificate),
+        BlockExpiredCertificate: util.YesNo(e.BlockExpiredCertificate),
+    }
+
+    return ans
+}
+
+/** Structs shared by all versions. **/
+
+type usernameField struct {
+    Subject string `xml:"subject,omitempty"`
+    SubjectAlt string `xml:"subject-alt,omitempty"`
+}
+
+func (o *usernameField) normalize() (string, string) {
+    switch {
+    case o == nil:
+    case o.Subject != "":
+        return UsernameFieldSubject, o.Subject
+    case o.SubjectAlt != "":
+        return UsernameFieldSubjectAlt, o.SubjectAlt
+    }
+
+    return "", ""
+}
+
+func specifyUsernameField(e Entry) *usernameField {
+    switch e.UsernameField {
+    case UsernameFieldSubject:
+        return &usernameField{Subject: e.UsernameFieldValue}
+    case UsernameFieldSubjectAlt:
+        return &usernameField{SubjectAlt: e.UsernameFieldValue}
+    }
+
+    return nil
+}
+
+type certs struct {
+    Entries []cert `xml:"entry"`
+}
+
+type cert struct {
+    Name string `xml:"name,attr"`
+    DefaultOcspUrl string `xml:"default-ocsp-url,omitempty"`
+    OcspVerifyCertificate string `xml:"ocsp-verify-cert,omitempty"`
+    TemplateName string `xml:"template-name,omitempty"`
+}
+
+func (o *certs) normalize() []Certificate {
+    if o == nil {
+        return nil
+    }
+
+    ans := make([]Certificate, 0, len(o.Entries))
+    for _, v := range o.Entries {
+        ans = append(ans, Certificate{
+            Name: v.Name,
+            DefaultOcspUrl: v.DefaultOcspUrl,
+            OcspVerifyCertificate: v.OcspVerifyCertificate,
+            TemplateName: v.TemplateName,
+        })
+    }
+
+    return ans
+}
+
+func specifyCerts(list []Certificate) *certs {
+    if len(list) == 0 {
+        return nil
+    }
+
+    ans := make([]cert, 0, len(list))
+    for _, v := range list {
+        ans = append(ans, cert{
+            Name: v.Name,
+            DefaultOcspUrl: v.DefaultOcspUrl,
+            OcspVerifyCertificate: v.OcspVerifyCertificate,
+            TemplateName: v.TemplateName,
+        })
+    }
+
+    return &certs{Entries: ans}
+}
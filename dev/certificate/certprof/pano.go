@@ -0,0 +1,176 @@
+package certprof
+
+import (
+    "fmt"
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+    "github.com/inwinstack/pango/version"
+)
+
+
+// PanoCertProf is the client.Device.CertificateProfile namespace.
+//
+// If both tmpl and ts are empty strings, then Panorama's own certificate
+// profiles are managed instead of the ones in a template or template stack.
+type PanoCertProf struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked by client.Initialize().
+func (c *PanoCertProf) Initialize(con util.XapiClient) {
+    c.con = con
+}
+
+// ShowList performs SHOW to retrieve a list of certificate profiles.
+func (c *PanoCertProf) ShowList(tmpl, ts, vsys string) ([]string, error) {
+    c.con.LogQuery("(show) list of %s", plural)
+    path := c.xpath(tmpl, ts, vsys, nil)
+    return c.con.EntryListUsing(c.con.Show, path[:len(path) - 1])
+}
+
+// GetList performs GET to retrieve a list of certificate profiles.
+func (c *PanoCertProf) GetList(tmpl, ts, vsys string) ([]string, error) {
+    c.con.LogQuery("(get) list of %s", plural)
+    path := c.xpath(tmpl, ts, vsys, nil)
+    return c.con.EntryListUsing(c.con.Get, path[:len(path) - 1])
+}
+
+// Get performs GET to retrieve information for the given certificate profile.
+func (c *PanoCertProf) Get(tmpl, ts, vsys, name string) (Entry, error) {
+    c.con.LogQuery("(get) %s %q", singular, name)
+    return c.details(c.con.Get, tmpl, ts, vsys, name)
+}
+
+// Show performs SHOW to retrieve information for the given certificate profile.
+func (c *PanoCertProf) Show(tmpl, ts, vsys, name string) (Entry, error) {
+    c.con.LogQuery("(show) %s %q", singular, name)
+    return c.details(c.con.Show, tmpl, ts, vsys, name)
+}
+
+// Set performs SET to create / update one or more certificate profiles.
+func (c *PanoCertProf) Set(tmpl, ts, vsys string, e ...Entry) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+
+    _, fn := c.versioning()
+    names := make([]string, len(e))
+
+    // Build up the struct.
+    d := util.BulkElement{XMLName: xml.Name{Local: "certificate-profile"}}
+    for i := range e {
+        d.Data = append(d.Data, fn(e[i]))
+        names[i] = e[i].Name
+    }
+    c.con.LogAction("(set) %s: %v", plural, names)
+
+    // Set xpath.
+    path := c.xpath(tmpl, ts, vsys, names)
+    if len(e) == 1 {
+        path = path[:len(path) - 1]
+    } else {
+        path = path[:len(path) - 2]
+    }
+
+    // Create the objects.
+    _, err = c.con.Set(path, d.Config(), nil, nil)
+    return err
+}
+
+// Edit performs EDIT to create / update one certificate profile.
+func (c *PanoCertProf) Edit(tmpl, ts, vsys string, e Entry) error {
+    var err error
+
+    _, fn := c.versioning()
+
+    c.con.LogAction("(edit) %s %q", singular, e.Name)
+
+    // Set xpath.
+    path := c.xpath(tmpl, ts, vsys, []string{e.Name})
+
+    // Edit the object.
+    _, err = c.con.Edit(path, fn(e), nil, nil)
+    return err
+}
+
+// Delete removes the given certificate profiles.
+//
+// Certificate profiles can be a string or an Entry object.
+func (c *PanoCertProf) Delete(tmpl, ts, vsys string, e ...interface{}) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+
+    names := make([]string, len(e))
+    for i := range e {
+        switch v := e[i].(type) {
+        case string:
+            names[i] = v
+        case Entry:
+            names[i] = v.Name
+        default:
+            return fmt.Errorf("Unknown type sent to delete: %s", v)
+        }
+    }
+    c.con.LogAction("(delete) %s: %v", plural, names)
+
+    // Remove the objects.
+    path := c.xpath(tmpl, ts, vsys, names)
+    _, err = c.con.Delete(path, nil, nil)
+    return err
+}
+
+/** Internal functions for this namespace struct **/
+
+func (c *PanoCertProf) versioning() (normalizer, func(Entry) (interface{})) {
+    v := c.con.Versioning()
+
+    if v.Gte(version.Number{9, 0, 0, ""}) {
+        return &container_v3{}, specify_v3
+    } else if v.Gte(version.Number{8, 1, 0, ""}) {
+        return &container_v2{}, specify_v2
+    } else {
+        return &container_v1{}, specify_v1
+    }
+}
+
+func (c *PanoCertProf) details(fn util.Retriever, tmpl, ts, vsys, name string) (Entry, error) {
+    path := c.xpath(tmpl, ts, vsys, []string{name})
+    obj, _ := c.versioning()
+    if _, err := fn(path, nil, obj); err != nil {
+        return Entry{}, err
+    }
+    ans := obj.Normalize()
+
+    return ans, nil
+}
+
+func (c *PanoCertProf) xpath(tmpl, ts, vsys string, vals []string) []string {
+    if tmpl == "" && ts == "" {
+        return []string{
+            "config",
+            "panorama",
+            "certificate-profile",
+            util.AsEntryXpath(vals),
+        }
+    }
+
+    if vsys == "" {
+        vsys = "shared"
+    }
+
+    ans := make([]string, 0, 12)
+    ans = append(ans, util.TemplateXpathPrefix(tmpl, ts)...)
+    ans = append(ans, util.VsysXpathPrefix(vsys)...)
+    ans = append(ans,
+        "certificate-profile",
+        util.AsEntryXpath(vals),
+    )
+
+    return ans
+}
@@ -0,0 +1,54 @@
+package certprof
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            UseCrl: true,
+            CrlReceiveTimeout: 5,
+        }},
+        {"v1 subject with ca", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t2",
+            UsernameField: UsernameFieldSubject,
+            UsernameFieldValue: UsernameFieldValueCommonName,
+            Domain: "example",
+            Certificates: []Certificate{
+                {"root", "http://ocsp.example.com", "ocsp-cert", "tmpl"},
+                {"intermediate", "", "", ""},
+            },
+            UseOcsp: true,
+            OcspReceiveTimeout: 10,
+            CertificateStatusTimeout: 15,
+            BlockUnknownCertificate: true,
+            BlockCertificateTimeout: true,
+        }},
+        {"v2 subject alt", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t3",
+            UsernameField: UsernameFieldSubjectAlt,
+            UsernameFieldValue: UsernameFieldValuePrincipalName,
+            Certificates: []Certificate{
+                {Name: "root"},
+            },
+            BlockUnauthenticatedCertificate: true,
+        }},
+        {"v3 block expired", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t4",
+            UsernameField: UsernameFieldSubjectAlt,
+            UsernameFieldValue: UsernameFieldValueEmail,
+            UseCrl: true,
+            UseOcsp: true,
+            BlockUnauthenticatedCertificate: true,
+            BlockExpiredCertificate: true,
+        }},
+    }
+}
@@ -0,0 +1,22 @@
+package certificate
+
+// Valid values for Entry.Algorithm and Generate.Algorithm.
+const (
+    AlgorithmRsa = "RSA"
+    AlgorithmEcdsa = "ECDSA"
+)
+
+// Valid values for Import.Format.
+const (
+    FormatPem = "pem"
+    FormatPkcs12 = "pkcs12"
+)
+
+// SignedByExternal is the value for Generate.SignedBy to generate a CSR to be
+// signed by an external CA.
+const SignedByExternal = "external"
+
+const (
+    singular = "certificate"
+    plural = "certificates"
+)
@@ -0,0 +1,11 @@
+/*
+Package certificate is the client.Device.Certificate namespace.
+
+Certificates are usually created on PAN-OS by generating them or by importing
+them, both of which are supported by the namespace structs in this package.
+The certificate details in an Entry, such as the issuer or expiry, are
+determined by PAN-OS from the certificate itself.
+
+Normalized object: Entry
+*/
+package certificate
@@ -0,0 +1,131 @@
+package certificate
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a
+// certificate.
+//
+// PublicKey is the PEM encoded certificate, Csr is the PEM encoded
+// certificate signing request for certificates that are pending signature by
+// an external CA, and PrivateKey is the private key as encrypted by PAN-OS.
+//
+// Subject, SubjectHash, Issuer, IssuerHash, NotValidBefore, NotValidAfter,
+// and ExpiryEpoch are computed by PAN-OS from the certificate itself.  They
+// are read-only: they are populated by Get / Show, but are not sent on Set /
+// Edit.
+type Entry struct {
+    Name string
+    CommonName string
+    Algorithm string
+    Ca bool
+    Subject string
+    SubjectHash string
+    Issuer string
+    IssuerHash string
+    NotValidBefore string
+    NotValidAfter string
+    ExpiryEpoch string
+    PublicKey string
+    Csr string
+    PrivateKey string
+    PrivateKeyOnHsm bool
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.CommonName = s.CommonName
+    o.Algorithm = s.Algorithm
+    o.Ca = s.Ca
+    o.PublicKey = s.PublicKey
+    o.Csr = s.Csr
+    o.PrivateKey = s.PrivateKey
+    o.PrivateKeyOnHsm = s.PrivateKeyOnHsm
+}
+
+/** Structs / functions for normalization. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        CommonName: o.Answer.CommonName,
+        Algorithm: o.Answer.Algorithm,
+        Ca: util.AsBool(o.Answer.Ca),
+        Subject: o.Answer.Subject,
+        SubjectHash: o.Answer.SubjectHash,
+        Issuer: o.Answer.Issuer,
+        IssuerHash: o.Answer.IssuerHash,
+        NotValidBefore: o.Answer.NotValidBefore,
+        NotValidAfter: o.Answer.NotValidAfter,
+        ExpiryEpoch: o.Answer.ExpiryEpoch,
+        PublicKey: o.Answer.PublicKey,
+        Csr: o.Answer.Csr,
+        PrivateKey: o.Answer.PrivateKey,
+        PrivateKeyOnHsm: util.AsBool(o.Answer.PrivateKeyOnHsm),
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    CommonName string `xml:"common-name,omitempty"`
+    Algorithm string `xml:"algorithm,omitempty"`
+    Ca string `xml:"ca"`
+    Subject string `xml:"subject,omitempty"`
+    SubjectHash string `xml:"subject-hash,omitempty"`
+    Issuer string `xml:"issuer,omitempty"`
+    IssuerHash string `xml:"issuer-hash,omitempty"`
+    NotValidBefore string `xml:"not-valid-before,omitempty"`
+    NotValidAfter string `xml:"not-valid-after,omitempty"`
+    ExpiryEpoch string `xml:"expiry-epoch,omitempty"`
+    PublicKey string `xml:"public-key,omitempty"`
+    Csr string `xml:"csr,omitempty"`
+    PrivateKey string `xml:"private-key,omitempty"`
+    PrivateKeyOnHsm string `xml:"private-key-on-hsm,omitempty"`
+}
+
+// spec_v1 is entry_v1 without the fields that are computed by PAN-OS.
+type spec_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    CommonName string `xml:"common-name,omitempty"`
+    Algorithm string `xml:"algorithm,omitempty"`
+    Ca string `xml:"ca"`
+    PublicKey string `xml:"public-key,omitempty"`
+    Csr string `xml:"csr,omitempty"`
+    PrivateKey string `xml:"private-key,omitempty"`
+    PrivateKeyOnHsm string `xml:"private-key-on-hsm,omitempty"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := spec_v1{
+        Name: e.Name,
+        CommonName: e.CommonName,
+        Algorithm: e.Algorithm,
+        Ca: util.YesNo(e.Ca),
+        PublicKey: e.PublicKey,
+        Csr: e.Csr,
+        PrivateKey: e.PrivateKey,
+    }
+
+    if e.PrivateKeyOnHsm {
+        ans.PrivateKeyOnHsm = "yes"
+    }
+
+    return ans
+}
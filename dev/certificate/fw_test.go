@@ -0,0 +1,231 @@
+package certificate
+
+import (
+    "testing"
+    "reflect"
+    "strings"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestFwNormalization(t *testing.T) {
+    testCases := getTests()
+
+    mc := &testdata.MockClient{}
+    ns := &FwCertificate{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            mc.AddResp("")
+            err := ns.Set("", tc.conf)
+            if err != nil {
+                t.Errorf("Error in set: %s", err)
+            } else {
+                mc.AddResp(mc.Elm)
+                r, err := ns.Get("", tc.conf.Name)
+                if err != nil {
+                    t.Errorf("Error in get: %s", err)
+                } else if !reflect.DeepEqual(tc.conf, r) {
+                    t.Errorf("%#v != %#v", tc.conf, r)
+                }
+            }
+        })
+    }
+}
+
+func TestFwReadOnlyFields(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &FwCertificate{}
+    ns.Initialize(mc)
+
+    mc.AddResp(`<entry name="root"><common-name>root.example.com</common-name><ca>yes</ca><subject>/CN=root.example.com</subject><subject-hash>1a2b3c4d</subject-hash><issuer>/CN=root.example.com</issuer><issuer-hash>1a2b3c4d</issuer-hash><not-valid-before>Jan  1 00:00:00 2020 GMT</not-valid-before><not-valid-after>Jan  1 00:00:00 2030 GMT</not-valid-after><expiry-epoch>1893456000</expiry-epoch></entry>`)
+    r, err := ns.Get("", "root")
+    if err != nil {
+        t.Fatalf("Error in get: %s", err)
+    }
+    expected := Entry{
+        Name: "root",
+        CommonName: "root.example.com",
+        Ca: true,
+        Subject: "/CN=root.example.com",
+        SubjectHash: "1a2b3c4d",
+        Issuer: "/CN=root.example.com",
+        IssuerHash: "1a2b3c4d",
+        NotValidBefore: "Jan  1 00:00:00 2020 GMT",
+        NotValidAfter: "Jan  1 00:00:00 2030 GMT",
+        ExpiryEpoch: "1893456000",
+    }
+    if !reflect.DeepEqual(expected, r) {
+        t.Fatalf("%#v != %#v", expected, r)
+    }
+
+    mc.AddResp("")
+    if err = ns.Edit("", r); err != nil {
+        t.Fatalf("Error in edit: %s", err)
+    }
+    for _, s := range []string{"subject", "issuer", "not-valid", "expiry-epoch"} {
+        if strings.Contains(mc.Elm, s) {
+            t.Errorf("Read-only field %q was sent: %s", s, mc.Elm)
+        }
+    }
+}
+
+func TestFwGenerate(t *testing.T) {
+    testCases := []struct{
+        desc string
+        vsys string
+        conf Generate
+        expVsys string
+        contains []string
+        missing []string
+    }{
+        {"self signed defaults", "", Generate{
+            Name: "c1",
+            CommonName: "c1.example.com",
+        }, "", []string{
+            "<certificate-name>c1</certificate-name>",
+            "<name>c1.example.com</name>",
+            "<algorithm><RSA><rsa-nbits>2048</rsa-nbits></RSA></algorithm>",
+            "<digest>sha256</digest>",
+        }, []string{"<signed-by>", "<ECDSA>", "<ca>"}},
+        {"csr in vsys", "vsys2", Generate{
+            Name: "c2",
+            CommonName: "c2.example.com",
+            SignedBy: SignedByExternal,
+            Algorithm: AlgorithmEcdsa,
+            Digest: "sha384",
+            Hostnames: []string{"c2.example.com", "www.example.com"},
+            OrganizationUnits: []string{"Ops"},
+        }, "vsys2", []string{
+            "<algorithm><ECDSA><ecdsa-nbits>256</ecdsa-nbits></ECDSA></algorithm>",
+            "<digest>sha384</digest>",
+            "<signed-by>external</signed-by>",
+            "<hostname><member>c2.example.com</member><member>www.example.com</member></hostname>",
+            "<organization-unit><member>Ops</member></organization-unit>",
+        }, []string{"<RSA>"}},
+        {"ca in shared", "shared", Generate{
+            Name: "c3",
+            CommonName: "ca",
+            Ca: true,
+            NumBits: 4096,
+            DaysTillExpiry: 730,
+        }, "", []string{
+            "<rsa-nbits>4096</rsa-nbits>",
+            "<ca>yes</ca>",
+            "<days-till-expiry>730</days-till-expiry>",
+        }, nil},
+    }
+
+    mc := &testdata.MockClient{}
+    ns := &FwCertificate{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            mc.AddResp("")
+            if err := ns.Generate(tc.vsys, tc.conf); err != nil {
+                t.Fatalf("Error in generate: %s", err)
+            }
+            if mc.Function != "op" {
+                t.Errorf("Function is %q, not op", mc.Function)
+            }
+            if mc.Vsys != tc.expVsys {
+                t.Errorf("Vsys is %q, not %q", mc.Vsys, tc.expVsys)
+            }
+            for _, s := range tc.contains {
+                if !strings.Contains(mc.Elm, s) {
+                    t.Errorf("Missing %s in %s", s, mc.Elm)
+                }
+            }
+            for _, s := range tc.missing {
+                if strings.Contains(mc.Elm, s) {
+                    t.Errorf("Unexpected %s in %s", s, mc.Elm)
+                }
+            }
+        })
+    }
+}
+
+func TestFwGenerateRequiresNames(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &FwCertificate{}
+    ns.Initialize(mc)
+
+    if err := ns.Generate("", Generate{CommonName: "cn"}); err == nil {
+        t.Errorf("Generate without name did not error")
+    }
+    if err := ns.Generate("", Generate{Name: "n"}); err == nil {
+        t.Errorf("Generate without common name did not error")
+    }
+    if err := ns.Generate("", Generate{Name: "n", CommonName: "cn", Algorithm: "DSA"}); err == nil {
+        t.Errorf("Generate with unknown algorithm did not error")
+    }
+}
+
+func TestFwImport(t *testing.T) {
+    testCases := []struct{
+        desc string
+        vsys string
+        conf Import
+        category string
+        format string
+        content string
+        expVsys string
+    }{
+        {"pem cert only", "", Import{
+            Name: "c1",
+            Certificate: "cert pem",
+        }, "certificate", FormatPem, "cert pem", ""},
+        {"pem with key", "vsys2", Import{
+            Name: "c2",
+            Certificate: "cert pem",
+            PrivateKey: "key pem",
+            Passphrase: "secret",
+        }, "private-key", FormatPem, "key pem", "vsys2"},
+        {"pkcs12", "shared", Import{
+            Name: "c3",
+            Format: FormatPkcs12,
+            Certificate: "p12 bundle",
+            Passphrase: "secret",
+        }, "keypair", FormatPkcs12, "p12 bundle", ""},
+    }
+
+    mc := &testdata.MockClient{}
+    ns := &FwCertificate{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            mc.AddResp("")
+            if err := ns.Import(tc.vsys, tc.conf); err != nil {
+                t.Fatalf("Error in import: %s", err)
+            }
+            if mc.Function != "import" {
+                t.Fatalf("Function is %q, not import", mc.Function)
+            }
+            if v := mc.Data.Get("category"); v != tc.category {
+                t.Errorf("Category is %q, not %q", v, tc.category)
+            }
+            if v := mc.Data.Get("format"); v != tc.format {
+                t.Errorf("Format is %q, not %q", v, tc.format)
+            }
+            if v := mc.Data.Get("certificate-name"); v != tc.conf.Name {
+                t.Errorf("Certificate name is %q, not %q", v, tc.conf.Name)
+            }
+            if v := mc.Data.Get("passphrase"); v != tc.conf.Passphrase {
+                t.Errorf("Passphrase is %q, not %q", v, tc.conf.Passphrase)
+            }
+            if v := mc.Data.Get("vsys"); v != tc.expVsys {
+                t.Errorf("Vsys is %q, not %q", v, tc.expVsys)
+            }
+            if mc.Elm != tc.content {
+                t.Errorf("Content is %q, not %q", mc.Elm, tc.content)
+            }
+        })
+    }
+}
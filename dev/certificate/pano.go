@@ -0,0 +1,205 @@
+package certificate
+
+import (
+    "fmt"
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// PanoCertificate is the client.Device.Certificate namespace.
+//
+// If both tmpl and ts are empty strings, then Panorama's own certificates are
+// managed instead of the certificates in a template or template stack.
+type PanoCertificate struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked by client.Initialize().
+func (c *PanoCertificate) Initialize(con util.XapiClient) {
+    c.con = con
+}
+
+// ShowList performs SHOW to retrieve a list of certificates.
+func (c *PanoCertificate) ShowList(tmpl, ts, vsys string) ([]string, error) {
+    c.con.LogQuery("(show) list of %s", plural)
+    path := c.xpath(tmpl, ts, vsys, nil)
+    return c.con.EntryListUsing(c.con.Show, path[:len(path) - 1])
+}
+
+// GetList performs GET to retrieve a list of certificates.
+func (c *PanoCertificate) GetList(tmpl, ts, vsys string) ([]string, error) {
+    c.con.LogQuery("(get) list of %s", plural)
+    path := c.xpath(tmpl, ts, vsys, nil)
+    return c.con.EntryListUsing(c.con.Get, path[:len(path) - 1])
+}
+
+// Get performs GET to retrieve information for the given certificate.
+func (c *PanoCertificate) Get(tmpl, ts, vsys, name string) (Entry, error) {
+    c.con.LogQuery("(get) %s %q", singular, name)
+    return c.details(c.con.Get, tmpl, ts, vsys, name)
+}
+
+// Show performs SHOW to retrieve information for the given certificate.
+func (c *PanoCertificate) Show(tmpl, ts, vsys, name string) (Entry, error) {
+    c.con.LogQuery("(show) %s %q", singular, name)
+    return c.details(c.con.Show, tmpl, ts, vsys, name)
+}
+
+// Set performs SET to create / update one or more certificates.
+func (c *PanoCertificate) Set(tmpl, ts, vsys string, e ...Entry) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+
+    _, fn := c.versioning()
+    names := make([]string, len(e))
+
+    // Build up the struct.
+    d := util.BulkElement{XMLName: xml.Name{Local: "certificate"}}
+    for i := range e {
+        d.Data = append(d.Data, fn(e[i]))
+        names[i] = e[i].Name
+    }
+    c.con.LogAction("(set) %s: %v", plural, names)
+
+    // Set xpath.
+    path := c.xpath(tmpl, ts, vsys, names)
+    if len(e) == 1 {
+        path = path[:len(path) - 1]
+    } else {
+        path = path[:len(path) - 2]
+    }
+
+    // Create the objects.
+    _, err = c.con.Set(path, d.Config(), nil, nil)
+    return err
+}
+
+// Edit performs EDIT to create / update one certificate.
+func (c *PanoCertificate) Edit(tmpl, ts, vsys string, e Entry) error {
+    var err error
+
+    _, fn := c.versioning()
+
+    c.con.LogAction("(edit) %s %q", singular, e.Name)
+
+    // Set xpath.
+    path := c.xpath(tmpl, ts, vsys, []string{e.Name})
+
+    // Edit the object.
+    _, err = c.con.Edit(path, fn(e), nil, nil)
+    return err
+}
+
+// Delete removes the given certificates.
+//
+// Certificates can be a string or an Entry object.
+func (c *PanoCertificate) Delete(tmpl, ts, vsys string, e ...interface{}) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+
+    names := make([]string, len(e))
+    for i := range e {
+        switch v := e[i].(type) {
+        case string:
+            names[i] = v
+        case Entry:
+            names[i] = v.Name
+        default:
+            return fmt.Errorf("Unknown type sent to delete: %s", v)
+        }
+    }
+    c.con.LogAction("(delete) %s: %v", plural, names)
+
+    // Remove the objects.
+    path := c.xpath(tmpl, ts, vsys, names)
+    _, err = c.con.Delete(path, nil, nil)
+    return err
+}
+
+// Generate generates a certificate on Panorama itself, or a CSR if
+// g.SignedBy is SignedByExternal.
+//
+// Certificates cannot be generated in a template; generate them on Panorama
+// and then Import them into the template instead.
+func (c *PanoCertificate) Generate(g Generate) error {
+    req, err := g.element()
+    if err != nil {
+        return err
+    }
+
+    c.con.LogOp("(op) generating %s %q", singular, g.Name)
+    _, err = c.con.Op(req, "", nil, nil)
+    return err
+}
+
+// Import uploads a certificate, and optionally its private key.
+//
+// If tmpl is an empty string, the certificate is imported into Panorama
+// itself.  Otherwise it is imported into the given template, in the given
+// vsys or shared if vsys is an empty string.  Template stacks are not
+// supported.
+func (c *PanoCertificate) Import(tmpl, ts, vsys string, i Import) error {
+    if ts != "" {
+        return fmt.Errorf("certificates cannot be imported into template stacks")
+    }
+
+    var loc map[string] string
+    if tmpl != "" {
+        loc = map[string] string{"target-tpl": tmpl}
+        if vsys != "" && vsys != "shared" {
+            loc["target-tpl-vsys"] = vsys
+        }
+    }
+
+    return doImport(c.con, i, loc)
+}
+
+/** Internal functions for this namespace struct **/
+
+func (c *PanoCertificate) versioning() (normalizer, func(Entry) (interface{})) {
+    return &container_v1{}, specify_v1
+}
+
+func (c *PanoCertificate) details(fn util.Retriever, tmpl, ts, vsys, name string) (Entry, error) {
+    path := c.xpath(tmpl, ts, vsys, []string{name})
+    obj, _ := c.versioning()
+    if _, err := fn(path, nil, obj); err != nil {
+        return Entry{}, err
+    }
+    ans := obj.Normalize()
+
+    return ans, nil
+}
+
+func (c *PanoCertificate) xpath(tmpl, ts, vsys string, vals []string) []string {
+    if tmpl == "" && ts == "" {
+        return []string{
+            "config",
+            "panorama",
+            "certificate",
+            util.AsEntryXpath(vals),
+        }
+    }
+
+    if vsys == "" {
+        vsys = "shared"
+    }
+
+    ans := make([]string, 0, 12)
+    ans = append(ans, util.TemplateXpathPrefix(tmpl, ts)...)
+    ans = append(ans, util.VsysXpathPrefix(vsys)...)
+    ans = append(ans,
+        "certificate",
+        util.AsEntryXpath(vals),
+    )
+
+    return ans
+}
@@ -0,0 +1,67 @@
+package certificate
+
+import (
+    "testing"
+    "reflect"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestPanoNormalization(t *testing.T) {
+    testCases := getTests()
+
+    mc := &testdata.MockClient{}
+    ns := &PanoCertificate{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            mc.AddResp("")
+            err := ns.Set("t1", "", "vsys1", tc.conf)
+            if err != nil {
+                t.Errorf("Error in set: %s", err)
+            } else {
+                mc.AddResp(mc.Elm)
+                r, err := ns.Get("t1", "", "vsys1", tc.conf.Name)
+                if err != nil {
+                    t.Errorf("Error in get: %s", err)
+                } else if !reflect.DeepEqual(tc.conf, r) {
+                    t.Errorf("%#v != %#v", tc.conf, r)
+                }
+            }
+        })
+    }
+}
+
+func TestPanoImport(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &PanoCertificate{}
+    ns.Initialize(mc)
+
+    mc.AddResp("")
+    err := ns.Import("t1", "", "vsys2", Import{Name: "c1", Certificate: "cert pem"})
+    if err != nil {
+        t.Fatalf("Error in import: %s", err)
+    }
+    if v := mc.Data.Get("target-tpl"); v != "t1" {
+        t.Errorf("Template is %q, not t1", v)
+    }
+    if v := mc.Data.Get("target-tpl-vsys"); v != "vsys2" {
+        t.Errorf("Template vsys is %q, not vsys2", v)
+    }
+
+    mc.Reset()
+    mc.AddResp("")
+    if err = ns.Import("", "", "", Import{Name: "c1", Certificate: "cert pem"}); err != nil {
+        t.Fatalf("Error in import: %s", err)
+    }
+    if v := mc.Data.Get("target-tpl"); v != "" {
+        t.Errorf("Panorama import has template %q", v)
+    }
+
+    if err = ns.Import("", "ts1", "", Import{Name: "c1", Certificate: "cert pem"}); err == nil {
+        t.Errorf("Import into a template stack did not error")
+    }
+}
@@ -0,0 +1,214 @@
+package certificate
+
+import (
+    "encoding/xml"
+    "fmt"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Generate describes a certificate to be generated by PAN-OS.
+//
+// Leave SignedBy empty for a self-signed certificate, set it to
+// SignedByExternal to generate a CSR, or set it to the name of a CA
+// certificate on the device to have that CA sign the new certificate.
+//
+// Algorithm defaults to RSA, NumBits defaults to 2048 for RSA and 256 for
+// ECDSA, and Digest defaults to sha256.
+type Generate struct {
+    Name string
+    CommonName string
+    SignedBy string
+    Ca bool
+    Algorithm string
+    NumBits int
+    Digest string
+    DaysTillExpiry int
+    Hostnames []string
+    IpAddresses []string
+    AlternateEmails []string
+    Country string
+    State string
+    Locality string
+    Organization string
+    OrganizationUnits []string
+    Email string
+    OcspResponderUrl string
+}
+
+// Import describes a certificate file to be imported into PAN-OS.
+//
+// For the PEM format, Certificate is the PEM encoded certificate, and
+// PrivateKey is the optional PEM encoded private key.  For the PKCS12 format,
+// Certificate is the PKCS12 bundle containing both the certificate and the
+// private key, and PrivateKey is unused.
+//
+// Passphrase is the passphrase of the private key, if any.
+type Import struct {
+    Name string
+    Format string
+    Certificate string
+    PrivateKey string
+    Passphrase string
+}
+
+func (o Generate) element() (interface{}, error) {
+    if o.Name == "" {
+        return nil, fmt.Errorf("certificate name must be specified")
+    } else if o.CommonName == "" {
+        return nil, fmt.Errorf("common name must be specified")
+    }
+
+    ans := genBody{
+        Name: o.Name,
+        CommonName: o.CommonName,
+        SignedBy: o.SignedBy,
+        Digest: o.Digest,
+        DaysTillExpiry: o.DaysTillExpiry,
+        Hostnames: util.StrToMem(o.Hostnames),
+        IpAddresses: util.StrToMem(o.IpAddresses),
+        AlternateEmails: util.StrToMem(o.AlternateEmails),
+        Country: o.Country,
+        State: o.State,
+        Locality: o.Locality,
+        Organization: o.Organization,
+        OrganizationUnits: util.StrToMem(o.OrganizationUnits),
+        Email: o.Email,
+        OcspResponderUrl: o.OcspResponderUrl,
+    }
+
+    if ans.Digest == "" {
+        ans.Digest = "sha256"
+    }
+
+    if o.Ca {
+        ans.Ca = "yes"
+    }
+
+    switch o.Algorithm {
+    case "", AlgorithmRsa:
+        ans.Algorithm.Rsa = &rsaBits{Bits: o.NumBits}
+        if o.NumBits == 0 {
+            ans.Algorithm.Rsa.Bits = 2048
+        }
+    case AlgorithmEcdsa:
+        ans.Algorithm.Ecdsa = &ecdsaBits{Bits: o.NumBits}
+        if o.NumBits == 0 {
+            ans.Algorithm.Ecdsa.Bits = 256
+        }
+    default:
+        return nil, fmt.Errorf("unknown algorithm: %s", o.Algorithm)
+    }
+
+    return genReq{Body: ans}, nil
+}
+
+// uploads returns the import categories and params for each file that needs
+// to be uploaded for this import.
+func (o Import) uploads() ([]upload, error) {
+    if o.Name == "" {
+        return nil, fmt.Errorf("certificate name must be specified")
+    } else if o.Certificate == "" {
+        return nil, fmt.Errorf("certificate content must be specified")
+    }
+
+    params := func(format string) map[string] string {
+        ans := map[string] string{
+            "certificate-name": o.Name,
+            "format": format,
+        }
+        if o.Passphrase != "" {
+            ans["passphrase"] = o.Passphrase
+        }
+        return ans
+    }
+
+    switch o.Format {
+    case "", FormatPem:
+        ans := []upload{
+            {"certificate", o.Name + ".pem", o.Certificate, params(FormatPem)},
+        }
+        if o.PrivateKey != "" {
+            ans = append(ans, upload{"private-key", o.Name + ".key", o.PrivateKey, params(FormatPem)})
+        }
+        return ans, nil
+    case FormatPkcs12:
+        if o.PrivateKey != "" {
+            return nil, fmt.Errorf("the private key is part of the pkcs12 bundle")
+        }
+        return []upload{
+            {"keypair", o.Name + ".p12", o.Certificate, params(FormatPkcs12)},
+        }, nil
+    }
+
+    return nil, fmt.Errorf("unknown format: %s", o.Format)
+}
+
+// doImport uploads the files for the given import, adding the given location
+// params to each upload.
+func doImport(con util.XapiClient, i Import, loc map[string] string) error {
+    list, err := i.uploads()
+    if err != nil {
+        return err
+    }
+
+    for _, u := range list {
+        for k, v := range loc {
+            u.extras[k] = v
+        }
+        con.LogOp("(import) %s %q", u.category, i.Name)
+        if _, err = con.Import(u.category, u.content, u.filename, "file", u.extras, nil); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+/** Internal structs **/
+
+type upload struct {
+    category string
+    filename string
+    content string
+    extras map[string] string
+}
+
+type genReq struct {
+    XMLName xml.Name `xml:"request"`
+    Body genBody `xml:"certificate>generate"`
+}
+
+type genBody struct {
+    Name string `xml:"certificate-name"`
+    CommonName string `xml:"name"`
+    Algorithm genAlgorithm `xml:"algorithm"`
+    Digest string `xml:"digest"`
+    Ca string `xml:"ca,omitempty"`
+    SignedBy string `xml:"signed-by,omitempty"`
+    DaysTillExpiry int `xml:"days-till-expiry,omitempty"`
+    Hostnames *util.MemberType `xml:"hostname"`
+    IpAddresses *util.MemberType `xml:"ip"`
+    AlternateEmails *util.MemberType `xml:"alt-email"`
+    Country string `xml:"country-code,omitempty"`
+    State string `xml:"state,omitempty"`
+    Locality string `xml:"locality,omitempty"`
+    Organization string `xml:"organization,omitempty"`
+    OrganizationUnits *util.MemberType `xml:"organization-unit"`
+    Email string `xml:"email,omitempty"`
+    OcspResponderUrl string `xml:"ocsp-responder-url,omitempty"`
+}
+
+type genAlgorithm struct {
+    Rsa *rsaBits `xml:"RSA"`
+    Ecdsa *ecdsaBits `xml:"ECDSA"`
+}
+
+type rsaBits struct {
+    Bits int `xml:"rsa-nbits"`
+}
+
+type ecdsaBits struct {
+    Bits int `xml:"ecdsa-nbits"`
+}
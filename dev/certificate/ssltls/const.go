@@ -0,0 +1,15 @@
+package ssltls
+
+// Valid values for MinVersion and MaxVersion.  TlsVersionMax is only valid
+// for MaxVersion.
+const (
+    TlsVersion10 = "tls1-0"
+    TlsVersion11 = "tls1-1"
+    TlsVersion12 = "tls1-2"
+    TlsVersionMax = "max"
+)
+
+const (
+    singular = "ssl/tls service profile"
+    plural = "ssl/tls service profiles"
+)
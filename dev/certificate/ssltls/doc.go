@@ -0,0 +1,6 @@
+/*
+Package ssltls is the client.Device.SslTlsServiceProfile namespace.
+
+Normalized object: Entry
+*/
+package ssltls
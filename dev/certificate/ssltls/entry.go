@@ -0,0 +1,216 @@
+package ssltls
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of an SSL/TLS
+// service profile.
+//
+// The Allow* params require PAN-OS 8.0+, and specify the key exchange,
+// encryption, and authentication algorithms allowed by the profile.  Use
+// Defaults() to allow all of them, which is the GUI default.
+type Entry struct {
+    Name string
+    Certificate string
+    MinVersion string
+    MaxVersion string
+    AllowRsa bool
+    AllowDhe bool
+    AllowEcdhe bool
+    Allow3des bool
+    AllowRc4 bool
+    AllowAes128Cbc bool
+    AllowAes256Cbc bool
+    AllowAes128Gcm bool
+    AllowAes256Gcm bool
+    AllowSha1 bool
+    AllowSha256 bool
+    AllowSha384 bool
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Certificate = s.Certificate
+    o.MinVersion = s.MinVersion
+    o.MaxVersion = s.MaxVersion
+    o.AllowRsa = s.AllowRsa
+    o.AllowDhe = s.AllowDhe
+    o.AllowEcdhe = s.AllowEcdhe
+    o.Allow3des = s.Allow3des
+    o.AllowRc4 = s.AllowRc4
+    o.AllowAes128Cbc = s.AllowAes128Cbc
+    o.AllowAes256Cbc = s.AllowAes256Cbc
+    o.AllowAes128Gcm = s.AllowAes128Gcm
+    o.AllowAes256Gcm = s.AllowAes256Gcm
+    o.AllowSha1 = s.AllowSha1
+    o.AllowSha256 = s.AllowSha256
+    o.AllowSha384 = s.AllowSha384
+}
+
+// Defaults sets params with uninitialized values to their GUI default setting.
+//
+// The defaults are as follows:
+//      * MinVersion: tls1-0
+//      * MaxVersion: max
+//      * Allow*: all true, if none of them are set
+func (o *Entry) Defaults() {
+    if o.MinVersion == "" {
+        o.MinVersion = TlsVersion10
+    }
+
+    if o.MaxVersion == "" {
+        o.MaxVersion = TlsVersionMax
+    }
+
+    if !(o.AllowRsa || o.AllowDhe || o.AllowEcdhe || o.Allow3des || o.AllowRc4 || o.AllowAes128Cbc || o.AllowAes256Cbc || o.AllowAes128Gcm || o.AllowAes256Gcm || o.AllowSha1 || o.AllowSha256 || o.AllowSha384) {
+        o.AllowRsa = true
+        o.AllowDhe = true
+        o.AllowEcdhe = true
+        o.Allow3des = true
+        o.AllowRc4 = true
+        o.AllowAes128Cbc = true
+        o.AllowAes256Cbc = true
+        o.AllowAes128Gcm = true
+        o.AllowAes256Gcm = true
+        o.AllowSha1 = true
+        o.AllowSha256 = true
+        o.AllowSha384 = true
+    }
+}
+
+/** Structs / functions for normalization. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Certificate: o.Answer.Certificate,
+    }
+
+    if o.Answer.Settings != nil {
+        ans.MinVersion = o.Answer.Settings.MinVersion
+        ans.MaxVersion = o.Answer.Settings.MaxVersion
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Certificate string `xml:"certificate"`
+    Settings *settings_v1 `xml:"protocol-settings"`
+}
+
+type settings_v1 struct {
+    MinVersion string `xml:"min-version,omitempty"`
+    MaxVersion string `xml:"max-version,omitempty"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Certificate: e.Certificate,
+    }
+
+    if e.MinVersion != "" || e.MaxVersion != "" {
+        ans.Settings = &settings_v1{
+            MinVersion: e.MinVersion,
+            MaxVersion: e.MaxVersion,
+        }
+    }
+
+    return ans
+}
+
+// PAN-OS 8.0+: algorithm settings added.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Certificate: o.Answer.Certificate,
+    }
+
+    if s := o.Answer.Settings; s != nil {
+        ans.MinVersion = s.MinVersion
+        ans.MaxVersion = s.MaxVersion
+        ans.AllowRsa = util.AsBool(s.AllowRsa)
+        ans.AllowDhe = util.AsBool(s.AllowDhe)
+        ans.AllowEcdhe = util.AsBool(s.AllowEcdhe)
+        ans.Allow3des = util.AsBool(s.Allow3des)
+        ans.AllowRc4 = util.AsBool(s.AllowRc4)
+        ans.AllowAes128Cbc = util.AsBool(s.AllowAes128Cbc)
+        ans.AllowAes256Cbc = util.AsBool(s.AllowAes256Cbc)
+        ans.AllowAes128Gcm = util.AsBool(s.AllowAes128Gcm)
+        ans.AllowAes256Gcm = util.AsBool(s.AllowAes256Gcm)
+        ans.AllowSha1 = util.AsBool(s.AllowSha1)
+        ans.AllowSha256 = util.AsBool(s.AllowSha256)
+        ans.AllowSha384 = util.AsBool(s.AllowSha384)
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Certificate string `xml:"certificate"`
+    Settings *settings_v2 `xml:"protocol-settings"`
+}
+
+type settings_v2 struct {
+    MinVersion string `xml:"min-version,omitempty"`
+    MaxVersion string `xml:"max-version,omitempty"`
+    AllowRsa string `xml:"keyxchg-algo-rsa"`
+    AllowDhe string `xml:"keyxchg-algo-dhe"`
+    AllowEcdhe string `xml:"keyxchg-algo-ecdhe"`
+    Allow3des string `xml:"enc-algo-3des"`
+    AllowRc4 string `xml:"enc-algo-rc4"`
+    AllowAes128Cbc string `xml:"enc-algo-aes-128-cbc"`
+    AllowAes256Cbc string `xml:"enc-algo-aes-256-cbc"`
+    AllowAes128Gcm string `xml:"enc-algo-aes-128-gcm"`
+    AllowAes256Gcm string `xml:"enc-algo-aes-256-gcm"`
+    AllowSha1 string `xml:"auth-algo-sha1"`
+    AllowSha256 string `xml:"auth-algo-sha256"`
+    AllowSha384 string `xml:"auth-algo-sha384"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        Certificate: e.Certificate,
+        Settings: &settings_v2{
+            MinVersion: e.MinVersion,
+            MaxVersion: e.MaxVersion,
+            AllowRsa: util.YesNo(e.AllowRsa),
+            AllowDhe: util.YesNo(e.AllowDhe),
+            AllowEcdhe: util.YesNo(e.AllowEcdhe),
+            Allow3des: util.YesNo(e.Allow3des),
+            AllowRc4: util.YesNo(e.AllowRc4),
+            AllowAes128Cbc: util.YesNo(e.AllowAes128Cbc),
+            AllowAes256Cbc: util.YesNo(e.AllowAes256Cbc),
+            AllowAes128Gcm: util.YesNo(e.AllowAes128Gcm),
+            AllowAes256Gcm: util.YesNo(e.AllowAes256Gcm),
+            AllowSha1: util.YesNo(e.AllowSha1),
+            AllowSha256: util.YesNo(e.AllowSha256),
+            AllowSha384: util.YesNo(e.AllowSha384),
+        },
+    }
+
+    return ans
+}
@@ -0,0 +1,57 @@
+package ssltls
+
+import (
+    "testing"
+    "reflect"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestFwNormalization(t *testing.T) {
+    testCases := getTests()
+
+    mc := &testdata.MockClient{}
+    ns := &FwSslTls{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Version = tc.version
+            mc.Reset()
+            mc.AddResp("")
+            err := ns.Set("", tc.conf)
+            if err != nil {
+                t.Errorf("Error in set: %s", err)
+            } else {
+                mc.AddResp(mc.Elm)
+                r, err := ns.Get("", tc.conf.Name)
+                if err != nil {
+                    t.Errorf("Error in get: %s", err)
+                } else if !reflect.DeepEqual(tc.conf, r) {
+                    t.Errorf("%#v != %#v", tc.conf, r)
+                }
+            }
+        })
+    }
+}
+
+func TestDefaults(t *testing.T) {
+    e := Entry{Name: "t1", AllowSha256: true}
+    e.Defaults()
+    if e.MinVersion != TlsVersion10 || e.MaxVersion != TlsVersionMax {
+        t.Errorf("Versions not defaulted: %#v", e)
+    }
+    if e.AllowRsa {
+        t.Errorf("Algorithms were defaulted even though one was set")
+    }
+
+    e = Entry{Name: "t2", MinVersion: TlsVersion12}
+    e.Defaults()
+    if e.MinVersion != TlsVersion12 {
+        t.Errorf("MinVersion was overwritten: %s", e.MinVersion)
+    }
+    if !e.AllowRsa || !e.AllowSha384 {
+        t.Errorf("Algorithms were not defaulted: %#v", e)
+    }
+}
@@ -0,0 +1,55 @@
+package ssltls
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 certificate only", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Certificate: "c1",
+        }},
+        {"v1 with versions", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Certificate: "c1",
+            MinVersion: TlsVersion11,
+            MaxVersion: TlsVersionMax,
+        }},
+        {"v2 some algorithms", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t3",
+            Certificate: "c2",
+            MinVersion: TlsVersion12,
+            MaxVersion: TlsVersion12,
+            AllowEcdhe: true,
+            AllowAes128Gcm: true,
+            AllowAes256Gcm: true,
+            AllowSha256: true,
+            AllowSha384: true,
+        }},
+        {"v2 all algorithms", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t4",
+            Certificate: "c3",
+            MinVersion: TlsVersion10,
+            MaxVersion: TlsVersionMax,
+            AllowRsa: true,
+            AllowDhe: true,
+            AllowEcdhe: true,
+            Allow3des: true,
+            AllowRc4: true,
+            AllowAes128Cbc: true,
+            AllowAes256Cbc: true,
+            AllowAes128Gcm: true,
+            AllowAes256Gcm: true,
+            AllowSha1: true,
+            AllowSha256: true,
+            AllowSha384: true,
+        }},
+    }
+}
@@ -0,0 +1,26 @@
+package certificate
+
+type tc struct {
+    desc string
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"self signed ca", Entry{
+            Name: "root",
+            CommonName: "root.example.com",
+            Algorithm: AlgorithmRsa,
+            Ca: true,
+            PublicKey: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+            PrivateKey: "-AQ==encrypted==",
+        }},
+        {"csr", Entry{
+            Name: "web",
+            CommonName: "www.example.com",
+            Algorithm: AlgorithmEcdsa,
+            Csr: "-----BEGIN CERTIFICATE REQUEST-----\nMIIB\n-----END CERTIFICATE REQUEST-----",
+            PrivateKeyOnHsm: true,
+        }},
+    }
+}
@@ -3,7 +3,11 @@ package dev
 import (
     "github.com/inwinstack/pango/util"
 
+    "github.com/inwinstack/pango/dev/certificate"
+    "github.com/inwinstack/pango/dev/certificate/certprof"
+    "github.com/inwinstack/pango/dev/certificate/ssltls"
     "github.com/inwinstack/pango/dev/general"
+    "github.com/inwinstack/pango/dev/ha"
     "github.com/inwinstack/pango/dev/profile/email"
     emailsrv "github.com/inwinstack/pango/dev/profile/email/server"
     "github.com/inwinstack/pango/dev/profile/http"
@@ -15,29 +19,42 @@ import (
     "github.com/inwinstack/pango/dev/profile/snmp/v3"
     "github.com/inwinstack/pango/dev/profile/syslog"
     syslogsrv "github.com/inwinstack/pango/dev/profile/syslog/server"
+    "github.com/inwinstack/pango/dev/sharedgw"
     "github.com/inwinstack/pango/dev/telemetry"
+    "github.com/inwinstack/pango/dev/vsys"
 )
 
-
 // FwDev is the client.Device namespace.
 type FwDev struct {
+    Certificate *certificate.FwCertificate
+    CertificateProfile *certprof.FwCertProf
     EmailServer *emailsrv.FwServer
     EmailServerProfile *email.FwEmail
     GeneralSettings *general.FwGeneral
+    HaConfig *ha.FwHa
     HttpHeader *header.FwHeader
     HttpParam *param.FwParam
     HttpServer *httpsrv.FwServer
     HttpServerProfile *http.FwHttp
+    SharedGateway *sharedgw.FwSharedGw
     SnmpServerProfile *snmp.FwSnmp
     SnmpV2cServer *v2c.FwV2c
     SnmpV3Server *v3.FwV3
+    SslTlsServiceProfile *ssltls.FwSslTls
     SyslogServer *syslogsrv.FwServer
     SyslogServerProfile *syslog.FwSyslog
     Telemetry *telemetry.FwTelemetry
+    Vsys *vsys.FwVsys
 }
 
 // Initialize is invoked on client.Initialize().
 func (c *FwDev) Initialize(i util.XapiClient) {
+    c.Certificate = &certificate.FwCertificate{}
+    c.Certificate.Initialize(i)
+
+    c.CertificateProfile = &certprof.FwCertProf{}
+    c.CertificateProfile.Initialize(i)
+
     c.EmailServer = &emailsrv.FwServer{}
     c.EmailServer.Initialize(i)
 
@@ -47,6 +64,9 @@ func (c *FwDev) Initialize(i util.XapiClient) {
     c.GeneralSettings = &general.FwGeneral{}
     c.GeneralSettings.Initialize(i)
 
+    c.HaConfig = &ha.FwHa{}
+    c.HaConfig.Initialize(i)
+
     c.HttpHeader = &header.FwHeader{}
     c.HttpHeader.Initialize(i)
 
@@ -59,6 +79,9 @@ func (c *FwDev) Initialize(i util.XapiClient) {
     c.HttpServerProfile = &http.FwHttp{}
     c.HttpServerProfile.Initialize(i)
 
+    c.SharedGateway = &sharedgw.FwSharedGw{}
+    c.SharedGateway.Initialize(i)
+
     c.SnmpServerProfile = &snmp.FwSnmp{}
     c.SnmpServerProfile.Initialize(i)
 
@@ -68,6 +91,9 @@ func (c *FwDev) Initialize(i util.XapiClient) {
     c.SnmpV3Server = &v3.FwV3{}
     c.SnmpV3Server.Initialize(i)
 
+    c.SslTlsServiceProfile = &ssltls.FwSslTls{}
+    c.SslTlsServiceProfile.Initialize(i)
+
     c.SyslogServer = &syslogsrv.FwServer{}
     c.SyslogServer.Initialize(i)
 
@@ -76,4 +102,7 @@ func (c *FwDev) Initialize(i util.XapiClient) {
 
     c.Telemetry = &telemetry.FwTelemetry{}
     c.Telemetry.Initialize(i)
+
+    c.Vsys = &vsys.FwVsys{}
+    c.Vsys.Initialize(i)
 }
@@ -0,0 +1,663 @@
+package ha
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Config is a normalized, version independent representation of a device's
+// high availability configuration.
+//
+// The Ha1 / Ha2 interfaces are the control and data links, and Ha3 is the
+// packet forwarding link used in active-active mode.
+type Config struct {
+    Enabled bool
+    GroupId int
+    Description string
+    PeerIp string
+    PeerIpBackup string
+    Mode string
+    PassiveLinkState string
+    DeviceId int
+    ConfigSync bool
+    StateSync bool
+    StateSyncTransport string
+    DevicePriority int
+    Preemptive bool
+    HeartbeatBackup bool
+    Ha1Port string
+    Ha1IpAddress string
+    Ha1Netmask string
+    Ha1Gateway string
+    Ha1BackupPort string
+    Ha1BackupIpAddress string
+    Ha1BackupNetmask string
+    Ha1BackupGateway string
+    Ha2Port string
+    Ha2IpAddress string
+    Ha2Netmask string
+    Ha2Gateway string
+    Ha2BackupPort string
+    Ha2BackupIpAddress string
+    Ha2BackupNetmask string
+    Ha2BackupGateway string
+    Ha3Port string
+    LinkMonitoring bool
+    LinkMonitoringFailureCondition string
+    LinkGroups []LinkGroup
+    PathMonitoring bool
+    PathMonitoringFailureCondition string
+    PathGroups []PathGroup
+
+    raw map[string] string
+}
+
+// LinkGroup is a link monitoring group.  The group fails based on the state
+// of its interfaces and its FailureCondition.
+type LinkGroup struct {
+    Name string
+    Enabled bool
+    FailureCondition string
+    Interfaces []string
+}
+
+// PathGroup is a path monitoring group.  Type is the kind of object being
+// monitored, and Name is the name of that virtual wire, VLAN, or virtual
+// router.
+type PathGroup struct {
+    Type string
+    Name string
+    Enabled bool
+    FailureCondition string
+    SourceIp string
+    DestinationGroups []DestinationGroup
+}
+
+// DestinationGroup is a group of destination IPs pinged by a path group.
+type DestinationGroup struct {
+    Name string
+    Enabled bool
+    FailureCondition string
+    DestinationIps []string
+}
+
+// Copy copies the information from source Config `s` to this object.
+func (o *Config) Copy(s Config) {
+    o.Enabled = s.Enabled
+    o.GroupId = s.GroupId
+    o.Description = s.Description
+    o.PeerIp = s.PeerIp
+    o.PeerIpBackup = s.PeerIpBackup
+    o.Mode = s.Mode
+    o.PassiveLinkState = s.PassiveLinkState
+    o.DeviceId = s.DeviceId
+    o.ConfigSync = s.ConfigSync
+    o.StateSync = s.StateSync
+    o.StateSyncTransport = s.StateSyncTransport
+    o.DevicePriority = s.DevicePriority
+    o.Preemptive = s.Preemptive
+    o.HeartbeatBackup = s.HeartbeatBackup
+    o.Ha1Port = s.Ha1Port
+    o.Ha1IpAddress = s.Ha1IpAddress
+    o.Ha1Netmask = s.Ha1Netmask
+    o.Ha1Gateway = s.Ha1Gateway
+    o.Ha1BackupPort = s.Ha1BackupPort
+    o.Ha1BackupIpAddress = s.Ha1BackupIpAddress
+    o.Ha1BackupNetmask = s.Ha1BackupNetmask
+    o.Ha1BackupGateway = s.Ha1BackupGateway
+    o.Ha2Port = s.Ha2Port
+    o.Ha2IpAddress = s.Ha2IpAddress
+    o.Ha2Netmask = s.Ha2Netmask
+    o.Ha2Gateway = s.Ha2Gateway
+    o.Ha2BackupPort = s.Ha2BackupPort
+    o.Ha2BackupIpAddress = s.Ha2BackupIpAddress
+    o.Ha2BackupNetmask = s.Ha2BackupNetmask
+    o.Ha2BackupGateway = s.Ha2BackupGateway
+    o.Ha3Port = s.Ha3Port
+    o.LinkMonitoring = s.LinkMonitoring
+    o.LinkMonitoringFailureCondition = s.LinkMonitoringFailureCondition
+    o.LinkGroups = s.LinkGroups
+    o.PathMonitoring = s.PathMonitoring
+    o.PathMonitoringFailureCondition = s.PathMonitoringFailureCondition
+    o.PathGroups = s.PathGroups
+}
+
+/** Structs / functions for normalization. **/
+
+type normalizer interface {
+    Normalize() Config
+}
+
+// PAN-OS 7.1: the HA group is an entry whose name is the group ID.
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>high-availability"`
+}
+
+func (o *container_v1) Normalize() Config {
+    ans := Config{
+        Enabled: util.AsBool(o.Answer.Enabled),
+        raw: make(map[string] string),
+    }
+
+    if o.Answer.Group != nil {
+        ans.GroupId = o.Answer.Group.GroupId
+        o.Answer.Group.normalize(&ans)
+    }
+    o.Answer.Interface.normalize(&ans)
+
+    if len(ans.raw) == 0 {
+        ans.raw = nil
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"high-availability"`
+    Enabled string `xml:"enabled"`
+    Group *groupEntry_v1 `xml:"group>entry"`
+    Interface *haInterface `xml:"interface"`
+}
+
+type groupEntry_v1 struct {
+    GroupId int `xml:"name,attr"`
+    groupBody
+}
+
+func specify_v1(e Config) interface{} {
+    ans := entry_v1{
+        Enabled: util.YesNo(e.Enabled),
+        Interface: specifyInterface(e),
+    }
+
+    if e.GroupId != 0 {
+        ans.Group = &groupEntry_v1{
+            GroupId: e.GroupId,
+            groupBody: specifyGroup(e),
+        }
+    }
+
+    return ans
+}
+
+// PAN-OS 8.0+: the group ID moved into the group itself.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>high-availability"`
+}
+
+func (o *container_v2) Normalize() Config {
+    ans := Config{
+        Enabled: util.AsBool(o.Answer.Enabled),
+        raw: make(map[string] string),
+    }
+
+    if o.Answer.Group != nil {
+        ans.GroupId = o.Answer.Group.GroupId
+        o.Answer.Group.normalize(&ans)
+    }
+    o.Answer.Interface.normalize(&ans)
+
+    if len(ans.raw) == 0 {
+        ans.raw = nil
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"high-availability"`
+    Enabled string `xml:"enabled"`
+    Group *group_v2 `xml:"group"`
+    Interface *haInterface `xml:"interface"`
+}
+
+type group_v2 struct {
+    GroupId int `xml:"group-id,omitempty"`
+    groupBody
+}
+
+func specify_v2(e Config) interface{} {
+    ans := entry_v2{
+        Enabled: util.YesNo(e.Enabled),
+        Interface: specifyInterface(e),
+    }
+
+    if e.GroupId != 0 {
+        ans.Group = &group_v2{
+            GroupId: e.GroupId,
+            groupBody: specifyGroup(e),
+        }
+    }
+
+    return ans
+}
+
+/** Structs shared by all versions. **/
+
+type groupBody struct {
+    Description string `xml:"description,omitempty"`
+    PeerIp string `xml:"peer-ip,omitempty"`
+    PeerIpBackup string `xml:"peer-ip-backup,omitempty"`
+    Mode *haMode `xml:"mode"`
+    ConfigSync string `xml:"configuration-synchronization>enabled"`
+    StateSync *stateSync `xml:"state-synchronization"`
+    Election *election `xml:"election-option"`
+    Monitoring *monitoring `xml:"monitoring"`
+}
+
+type haMode struct {
+    ActivePassive *activePassive `xml:"active-passive"`
+    ActiveActive *activeActive `xml:"active-active"`
+}
+
+type activePassive struct {
+    PassiveLinkState string `xml:"passive-link-state,omitempty"`
+}
+
+type activeActive struct {
+    DeviceId int `xml:"device-id,omitempty"`
+    NetworkConfiguration *util.RawXml `xml:"network-configuration"`
+    VirtualAddress *util.RawXml `xml:"virtual-address"`
+    SessionOwnerSelection *util.RawXml `xml:"session-owner-selection"`
+    SessionSetup *util.RawXml `xml:"session-setup"`
+}
+
+type stateSync struct {
+    Enabled string `xml:"enabled"`
+    Transport string `xml:"transport,omitempty"`
+    Ha2KeepAlive *util.RawXml `xml:"ha2-keep-alive"`
+}
+
+type election struct {
+    DevicePriority int `xml:"device-priority,omitempty"`
+    Preemptive string `xml:"preemptive"`
+    HeartbeatBackup string `xml:"heartbeat-backup"`
+    Timers *util.RawXml `xml:"timers"`
+}
+
+type monitoring struct {
+    Link *linkMonitoring `xml:"link-monitoring"`
+    Path *pathMonitoring `xml:"path-monitoring"`
+}
+
+type linkMonitoring struct {
+    Enabled string `xml:"enabled"`
+    FailureCondition string `xml:"failure-condition,omitempty"`
+    Groups *linkGroups `xml:"link-group"`
+}
+
+type linkGroups struct {
+    Entries []linkGroup `xml:"entry"`
+}
+
+type linkGroup struct {
+    Name string `xml:"name,attr"`
+    Enabled string `xml:"enabled"`
+    FailureCondition string `xml:"failure-condition,omitempty"`
+    Interfaces *util.MemberType `xml:"interface"`
+}
+
+type pathMonitoring struct {
+    Enabled string `xml:"enabled"`
+    FailureCondition string `xml:"failure-condition,omitempty"`
+    Groups *pathGroups `xml:"path-group"`
+}
+
+type pathGroups struct {
+    VirtualWires []pathGroup `xml:"virtual-wire>entry"`
+    Vlans []pathGroup `xml:"vlan>entry"`
+    VirtualRouters []pathGroup `xml:"virtual-router>entry"`
+}
+
+type pathGroup struct {
+    Name string `xml:"name,attr"`
+    Enabled string `xml:"enabled"`
+    FailureCondition string `xml:"failure-condition,omitempty"`
+    SourceIp string `xml:"source-ip,omitempty"`
+    DestinationGroups *destGroups `xml:"destination-ip-group"`
+}
+
+type destGroups struct {
+    Entries []destGroup `xml:"entry"`
+}
+
+type destGroup struct {
+    Name string `xml:"name,attr"`
+    Enabled string `xml:"enabled"`
+    FailureCondition string `xml:"failure-condition,omitempty"`
+    DestinationIps *util.MemberType `xml:"destination-ip"`
+}
+
+type haInterface struct {
+    Ha1 *haLink `xml:"ha1"`
+    Ha1Backup *haLink `xml:"ha1-backup"`
+    Ha2 *haLink `xml:"ha2"`
+    Ha2Backup *haLink `xml:"ha2-backup"`
+    Ha3 *haLink `xml:"ha3"`
+}
+
+type haLink struct {
+    Port string `xml:"port,omitempty"`
+    IpAddress string `xml:"ip-address,omitempty"`
+    Netmask string `xml:"netmask,omitempty"`
+    Gateway string `xml:"gateway,omitempty"`
+    Encryption *util.RawXml `xml:"encryption"`
+}
+
+func (o *groupBody) normalize(ans *Config) {
+    ans.Description = o.Description
+    ans.PeerIp = o.PeerIp
+    ans.PeerIpBackup = o.PeerIpBackup
+    ans.ConfigSync = util.AsBool(o.ConfigSync)
+
+    if o.Mode != nil {
+        switch {
+        case o.Mode.ActivePassive != nil:
+            ans.Mode = ModeActivePassive
+            ans.PassiveLinkState = o.Mode.ActivePassive.PassiveLinkState
+        case o.Mode.ActiveActive != nil:
+            aa := o.Mode.ActiveActive
+            ans.Mode = ModeActiveActive
+            ans.DeviceId = aa.DeviceId
+            if aa.NetworkConfiguration != nil {
+                ans.raw["aanc"] = util.CleanRawXml(aa.NetworkConfiguration.Text)
+            }
+            if aa.VirtualAddress != nil {
+                ans.raw["aava"] = util.CleanRawXml(aa.VirtualAddress.Text)
+            }
+            if aa.SessionOwnerSelection != nil {
+                ans.raw["aasos"] = util.CleanRawXml(aa.SessionOwnerSelection.Text)
+            }
+            if aa.SessionSetup != nil {
+                ans.raw["aass"] = util.CleanRawXml(aa.SessionSetup.Text)
+            }
+        }
+    }
+
+    if o.StateSync != nil {
+        ans.StateSync = util.AsBool(o.StateSync.Enabled)
+        ans.StateSyncTransport = o.StateSync.Transport
+        if o.StateSync.Ha2KeepAlive != nil {
+            ans.raw["ha2ka"] = util.CleanRawXml(o.StateSync.Ha2KeepAlive.Text)
+        }
+    }
+
+    if o.Election != nil {
+        ans.DevicePriority = o.Election.DevicePriority
+        ans.Preemptive = util.AsBool(o.Election.Preemptive)
+        ans.HeartbeatBackup = util.AsBool(o.Election.HeartbeatBackup)
+        if o.Election.Timers != nil {
+            ans.raw["timers"] = util.CleanRawXml(o.Election.Timers.Text)
+        }
+    }
+
+    if o.Monitoring == nil {
+        return
+    }
+
+    if o.Monitoring.Link != nil {
+        ans.LinkMonitoring = util.AsBool(o.Monitoring.Link.Enabled)
+        ans.LinkMonitoringFailureCondition = o.Monitoring.Link.FailureCondition
+        if o.Monitoring.Link.Groups != nil {
+            ans.LinkGroups = make([]LinkGroup, 0, len(o.Monitoring.Link.Groups.Entries))
+            for _, g := range o.Monitoring.Link.Groups.Entries {
+                ans.LinkGroups = append(ans.LinkGroups, LinkGroup{
+                    Name: g.Name,
+                    Enabled: util.AsBool(g.Enabled),
+                    FailureCondition: g.FailureCondition,
+                    Interfaces: util.MemToStr(g.Interfaces),
+                })
+            }
+        }
+    }
+
+    if o.Monitoring.Path != nil {
+        ans.PathMonitoring = util.AsBool(o.Monitoring.Path.Enabled)
+        ans.PathMonitoringFailureCondition = o.Monitoring.Path.FailureCondition
+        if p := o.Monitoring.Path.Groups; p != nil {
+            if n := len(p.VirtualWires) + len(p.Vlans) + len(p.VirtualRouters); n > 0 {
+                ans.PathGroups = make([]PathGroup, 0, n)
+                ans.PathGroups = normalizePathGroups(ans.PathGroups, PathGroupVirtualWire, p.VirtualWires)
+                ans.PathGroups = normalizePathGroups(ans.PathGroups, PathGroupVlan, p.Vlans)
+                ans.PathGroups = normalizePathGroups(ans.PathGroups, PathGroupVirtualRouter, p.VirtualRouters)
+            }
+        }
+    }
+}
+
+func normalizePathGroups(list []PathGroup, kind string, groups []pathGroup) []PathGroup {
+    for _, g := range groups {
+        pg := PathGroup{
+            Type: kind,
+            Name: g.Name,
+            Enabled: util.AsBool(g.Enabled),
+            FailureCondition: g.FailureCondition,
+            SourceIp: g.SourceIp,
+        }
+        if g.DestinationGroups != nil {
+            pg.DestinationGroups = make([]DestinationGroup, 0, len(g.DestinationGroups.Entries))
+            for _, d := range g.DestinationGroups.Entries {
+                pg.DestinationGroups = append(pg.DestinationGroups, DestinationGroup{
+                    Name: d.Name,
+                    Enabled: util.AsBool(d.Enabled),
+                    FailureCondition: d.FailureCondition,
+                    DestinationIps: util.MemToStr(d.DestinationIps),
+                })
+            }
+        }
+        list = append(list, pg)
+    }
+
+    return list
+}
+
+func (o *haInterface) normalize(ans *Config) {
+    if o == nil {
+        return
+    }
+
+    if o.Ha1 != nil {
+        ans.Ha1Port = o.Ha1.Port
+        ans.Ha1IpAddress = o.Ha1.IpAddress
+        ans.Ha1Netmask = o.Ha1.Netmask
+        ans.Ha1Gateway = o.Ha1.Gateway
+        if o.Ha1.Encryption != nil {
+            ans.raw["ha1enc"] = util.CleanRawXml(o.Ha1.Encryption.Text)
+        }
+    }
+    if o.Ha1Backup != nil {
+        ans.Ha1BackupPort = o.Ha1Backup.Port
+        ans.Ha1BackupIpAddress = o.Ha1Backup.IpAddress
+        ans.Ha1BackupNetmask = o.Ha1Backup.Netmask
+        ans.Ha1BackupGateway = o.Ha1Backup.Gateway
+    }
+    if o.Ha2 != nil {
+        ans.Ha2Port = o.Ha2.Port
+        ans.Ha2IpAddress = o.Ha2.IpAddress
+        ans.Ha2Netmask = o.Ha2.Netmask
+        ans.Ha2Gateway = o.Ha2.Gateway
+    }
+    if o.Ha2Backup != nil {
+        ans.Ha2BackupPort = o.Ha2Backup.Port
+        ans.Ha2BackupIpAddress = o.Ha2Backup.IpAddress
+        ans.Ha2BackupNetmask = o.Ha2Backup.Netmask
+        ans.Ha2BackupGateway = o.Ha2Backup.Gateway
+    }
+    if o.Ha3 != nil {
+        ans.Ha3Port = o.Ha3.Port
+    }
+}
+
+func specifyGroup(e Config) groupBody {
+    ans := groupBody{
+        Description: e.Description,
+        PeerIp: e.PeerIp,
+        PeerIpBackup: e.PeerIpBackup,
+        ConfigSync: util.YesNo(e.ConfigSync),
+        Election: &election{
+            DevicePriority: e.DevicePriority,
+            Preemptive: util.YesNo(e.Preemptive),
+            HeartbeatBackup: util.YesNo(e.HeartbeatBackup),
+        },
+    }
+
+    switch e.Mode {
+    case ModeActivePassive:
+        ans.Mode = &haMode{ActivePassive: &activePassive{
+            PassiveLinkState: e.PassiveLinkState,
+        }}
+    case ModeActiveActive:
+        aa := &activeActive{
+            DeviceId: e.DeviceId,
+        }
+        if text, present := e.raw["aanc"]; present {
+            aa.NetworkConfiguration = &util.RawXml{text}
+        }
+        if text, present := e.raw["aava"]; present {
+            aa.VirtualAddress = &util.RawXml{text}
+        }
+        if text, present := e.raw["aasos"]; present {
+            aa.SessionOwnerSelection = &util.RawXml{text}
+        }
+        if text, present := e.raw["aass"]; present {
+            aa.SessionSetup = &util.RawXml{text}
+        }
+        ans.Mode = &haMode{ActiveActive: aa}
+    }
+
+    text, keepAlive := e.raw["ha2ka"]
+    if e.StateSync || e.StateSyncTransport != "" || keepAlive {
+        ans.StateSync = &stateSync{
+            Enabled: util.YesNo(e.StateSync),
+            Transport: e.StateSyncTransport,
+        }
+        if keepAlive {
+            ans.StateSync.Ha2KeepAlive = &util.RawXml{text}
+        }
+    }
+
+    if text, present := e.raw["timers"]; present {
+        ans.Election.Timers = &util.RawXml{text}
+    }
+
+    if e.LinkMonitoring || e.LinkMonitoringFailureCondition != "" || len(e.LinkGroups) > 0 || e.PathMonitoring || e.PathMonitoringFailureCondition != "" || len(e.PathGroups) > 0 {
+        ans.Monitoring = &monitoring{}
+    }
+
+    if e.LinkMonitoring || e.LinkMonitoringFailureCondition != "" || len(e.LinkGroups) > 0 {
+        ans.Monitoring.Link = &linkMonitoring{
+            Enabled: util.YesNo(e.LinkMonitoring),
+            FailureCondition: e.LinkMonitoringFailureCondition,
+        }
+        if len(e.LinkGroups) > 0 {
+            list := make([]linkGroup, 0, len(e.LinkGroups))
+            for _, g := range e.LinkGroups {
+                list = append(list, linkGroup{
+                    Name: g.Name,
+                    Enabled: util.YesNo(g.Enabled),
+                    FailureCondition: g.FailureCondition,
+                    Interfaces: util.StrToMem(g.Interfaces),
+                })
+            }
+            ans.Monitoring.Link.Groups = &linkGroups{Entries: list}
+        }
+    }
+
+    if e.PathMonitoring || e.PathMonitoringFailureCondition != "" || len(e.PathGroups) > 0 {
+        ans.Monitoring.Path = &pathMonitoring{
+            Enabled: util.YesNo(e.PathMonitoring),
+            FailureCondition: e.PathMonitoringFailureCondition,
+        }
+        if len(e.PathGroups) > 0 {
+            p := &pathGroups{}
+            for _, g := range e.PathGroups {
+                pg := pathGroup{
+                    Name: g.Name,
+                    Enabled: util.YesNo(g.Enabled),
+                    FailureCondition: g.FailureCondition,
+                    SourceIp: g.SourceIp,
+                }
+                if len(g.DestinationGroups) > 0 {
+                    list := make([]destGroup, 0, len(g.DestinationGroups))
+                    for _, d := range g.DestinationGroups {
+                        list = append(list, destGroup{
+                            Name: d.Name,
+                            Enabled: util.YesNo(d.Enabled),
+                            FailureCondition: d.FailureCondition,
+                            DestinationIps: util.StrToMem(d.DestinationIps),
+                        })
+                    }
+                    pg.DestinationGroups = &destGroups{Entries: list}
+                }
+                switch g.Type {
+                case PathGroupVirtualWire:
+                    p.VirtualWires = append(p.VirtualWires, pg)
+                case PathGroupVlan:
+                    p.Vlans = append(p.Vlans, pg)
+                case PathGroupVirtualRouter:
+                    p.VirtualRouters = append(p.VirtualRouters, pg)
+                }
+            }
+            ans.Monitoring.Path.Groups = p
+        }
+    }
+
+    return ans
+}
+
+func specifyInterface(e Config) *haInterface {
+    ans := &haInterface{}
+    var present bool
+
+    text, encryption := e.raw["ha1enc"]
+    if e.Ha1Port != "" || e.Ha1IpAddress != "" || encryption {
+        present = true
+        ans.Ha1 = &haLink{
+            Port: e.Ha1Port,
+            IpAddress: e.Ha1IpAddress,
+            Netmask: e.Ha1Netmask,
+            Gateway: e.Ha1Gateway,
+        }
+        if encryption {
+            ans.Ha1.Encryption = &util.RawXml{text}
+        }
+    }
+    if e.Ha1BackupPort != "" || e.Ha1BackupIpAddress != "" {
+        present = true
+        ans.Ha1Backup = &haLink{
+            Port: e.Ha1BackupPort,
+            IpAddress: e.Ha1BackupIpAddress,
+            Netmask: e.Ha1BackupNetmask,
+            Gateway: e.Ha1BackupGateway,
+        }
+    }
+    if e.Ha2Port != "" || e.Ha2IpAddress != "" {
+        present = true
+        ans.Ha2 = &haLink{
+            Port: e.Ha2Port,
+            IpAddress: e.Ha2IpAddress,
+            Netmask: e.Ha2Netmask,
+            Gateway: e.Ha2Gateway,
+        }
+    }
+    if e.Ha2BackupPort != "" || e.Ha2BackupIpAddress != "" {
+        present = true
+        ans.Ha2Backup = &haLink{
+            Port: e.Ha2BackupPort,
+            IpAddress: e.Ha2BackupIpAddress,
+            Netmask: e.Ha2BackupNetmask,
+            Gateway: e.Ha2BackupGateway,
+        }
+    }
+    if e.Ha3Port != "" {
+        present = true
+        ans.Ha3 = &haLink{Port: e.Ha3Port}
+    }
+
+    if !present {
+        return nil
+    }
+    return ans
+}
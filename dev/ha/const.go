@@ -0,0 +1,33 @@
+package ha
+
+// Valid values for Mode.
+const (
+    ModeActivePassive = "active-passive"
+    ModeActiveActive = "active-active"
+)
+
+// Valid values for PassiveLinkState.
+const (
+    PassiveLinkStateShutdown = "shutdown"
+    PassiveLinkStateAuto = "auto"
+)
+
+// Valid values for StateSyncTransport.
+const (
+    TransportEthernet = "ethernet"
+    TransportIp = "ip"
+    TransportUdp = "udp"
+)
+
+// Valid values for the various FailureCondition params.
+const (
+    FailureConditionAny = "any"
+    FailureConditionAll = "all"
+)
+
+// Valid values for PathGroup.Type.
+const (
+    PathGroupVirtualWire = "virtual-wire"
+    PathGroupVlan = "vlan"
+    PathGroupVirtualRouter = "virtual-router"
+)
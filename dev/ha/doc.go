@@ -0,0 +1,10 @@
+/*
+Package ha is the client.Device.HaConfig namespace.
+
+This configures high availability between a pair of firewalls.  To control a
+running HA pair (suspend, failover, config sync), use the functions on the
+client itself instead.
+
+Normalized object: Config
+*/
+package ha
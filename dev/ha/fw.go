@@ -0,0 +1,96 @@
+package ha
+
+import (
+    "github.com/inwinstack/pango/util"
+    "github.com/inwinstack/pango/version"
+)
+
+
+// FwHa is a namespace struct, included as part of pango.Firewall.
+type FwHa struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked by client.Initialize().
+func (c *FwHa) Initialize(con util.XapiClient) {
+    c.con = con
+}
+
+// Show performs SHOW to retrieve the HA config.
+func (c *FwHa) Show() (Config, error) {
+    c.con.LogQuery("(show) high availability config")
+    return c.details(c.con.Show)
+}
+
+// Get performs GET to retrieve the HA config.
+func (c *FwHa) Get() (Config, error) {
+    c.con.LogQuery("(get) high availability config")
+    return c.details(c.con.Get)
+}
+
+// Set performs SET to update the HA config.
+func (c *FwHa) Set(e Config) error {
+    var err error
+    _, fn := c.versioning()
+    c.con.LogAction("(set) high availability config")
+
+    path := c.xpath()
+    path = path[:len(path) - 1]
+
+    _, err = c.con.Set(path, fn(e), nil, nil)
+    return err
+}
+
+// Edit performs EDIT to update the HA config.
+func (c *FwHa) Edit(e Config) error {
+    var err error
+    _, fn := c.versioning()
+    c.con.LogAction("(edit) high availability config")
+
+    path := c.xpath()
+
+    _, err = c.con.Edit(path, fn(e), nil, nil)
+    return err
+}
+
+// Delete removes all HA config from the firewall.
+func (c *FwHa) Delete() error {
+    c.con.LogAction("(delete) high availability config")
+    path := c.xpath()
+
+    _, err := c.con.Delete(path, nil, nil)
+    return err
+}
+
+/** Internal functions for the FwHa struct **/
+
+func (c *FwHa) versioning() (normalizer, func(Config) (interface{})) {
+    v := c.con.Versioning()
+
+    if v.Gte(version.Number{8, 0, 0, ""}) {
+        return &container_v2{}, specify_v2
+    } else {
+        return &container_v1{}, specify_v1
+    }
+}
+
+func (c *FwHa) details(fn util.Retriever) (Config, error) {
+    path := c.xpath()
+    obj, _ := c.versioning()
+    if _, err := fn(path, nil, obj); err != nil {
+        return Config{}, err
+    }
+    ans := obj.Normalize()
+
+    return ans, nil
+}
+
+func (c *FwHa) xpath() []string {
+    return []string{
+        "config",
+        "devices",
+        util.AsEntryXpath([]string{"localhost.localdomain"}),
+        "deviceconfig",
+        "high-availability",
+    }
+}
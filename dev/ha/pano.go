@@ -0,0 +1,123 @@
+package ha
+
+import (
+    "fmt"
+
+    "github.com/inwinstack/pango/util"
+    "github.com/inwinstack/pango/version"
+)
+
+
+// PanoHa is a namespace struct, included as part of pango.Panorama.
+//
+// The HA config is managed in a template or template stack, to be pushed
+// to firewalls.
+type PanoHa struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked by client.Initialize().
+func (c *PanoHa) Initialize(con util.XapiClient) {
+    c.con = con
+}
+
+// Show performs SHOW to retrieve the HA config.
+func (c *PanoHa) Show(tmpl, ts string) (Config, error) {
+    c.con.LogQuery("(show) high availability config")
+    return c.details(c.con.Show, tmpl, ts)
+}
+
+// Get performs GET to retrieve the HA config.
+func (c *PanoHa) Get(tmpl, ts string) (Config, error) {
+    c.con.LogQuery("(get) high availability config")
+    return c.details(c.con.Get, tmpl, ts)
+}
+
+// Set performs SET to update the HA config.
+func (c *PanoHa) Set(tmpl, ts string, e Config) error {
+    var err error
+
+    if tmpl == "" && ts == "" {
+        return fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    _, fn := c.versioning()
+    c.con.LogAction("(set) high availability config")
+
+    path := c.xpath(tmpl, ts)
+    path = path[:len(path) - 1]
+
+    _, err = c.con.Set(path, fn(e), nil, nil)
+    return err
+}
+
+// Edit performs EDIT to update the HA config.
+func (c *PanoHa) Edit(tmpl, ts string, e Config) error {
+    var err error
+
+    if tmpl == "" && ts == "" {
+        return fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    _, fn := c.versioning()
+    c.con.LogAction("(edit) high availability config")
+
+    path := c.xpath(tmpl, ts)
+
+    _, err = c.con.Edit(path, fn(e), nil, nil)
+    return err
+}
+
+// Delete removes all HA config from the template or template stack.
+func (c *PanoHa) Delete(tmpl, ts string) error {
+    if tmpl == "" && ts == "" {
+        return fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    c.con.LogAction("(delete) high availability config")
+    path := c.xpath(tmpl, ts)
+
+    _, err := c.con.Delete(path, nil, nil)
+    return err
+}
+
+/** Internal functions for the PanoHa struct **/
+
+func (c *PanoHa) versioning() (normalizer, func(Config) (interface{})) {
+    v := c.con.Versioning()
+
+    if v.Gte(version.Number{8, 0, 0, ""}) {
+        return &container_v2{}, specify_v2
+    } else {
+        return &container_v1{}, specify_v1
+    }
+}
+
+func (c *PanoHa) details(fn util.Retriever, tmpl, ts string) (Config, error) {
+    if tmpl == "" && ts == "" {
+        return Config{}, fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    path := c.xpath(tmpl, ts)
+    obj, _ := c.versioning()
+    if _, err := fn(path, nil, obj); err != nil {
+        return Config{}, err
+    }
+    ans := obj.Normalize()
+
+    return ans, nil
+}
+
+func (c *PanoHa) xpath(tmpl, ts string) []string {
+    ans := make([]string, 0, 10)
+    ans = append(ans, util.TemplateXpathPrefix(tmpl, ts)...)
+    ans = append(ans,
+        "config",
+        "devices",
+        util.AsEntryXpath([]string{"localhost.localdomain"}),
+        "deviceconfig",
+        "high-availability",
+    )
+
+    return ans
+}
@@ -0,0 +1,106 @@
+package ha
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Config
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 disabled", version.Number{7, 1, 0, ""}, Config{}},
+        {"v1 active passive", version.Number{7, 1, 0, ""}, Config{
+            Enabled: true,
+            GroupId: 5,
+            Description: "my ha",
+            PeerIp: "10.1.1.2",
+            Mode: ModeActivePassive,
+            PassiveLinkState: PassiveLinkStateAuto,
+            StateSync: true,
+            DevicePriority: 90,
+            Preemptive: true,
+            Ha1Port: "management",
+            Ha2Port: "ethernet1/8",
+            Ha2IpAddress: "10.2.1.1",
+            Ha2Netmask: "255.255.255.0",
+        }},
+        {"v2 active passive with timers", version.Number{8, 0, 0, ""}, Config{
+            Enabled: true,
+            GroupId: 1,
+            PeerIp: "10.1.1.2",
+            PeerIpBackup: "10.1.2.2",
+            Mode: ModeActivePassive,
+            PassiveLinkState: PassiveLinkStateShutdown,
+            ConfigSync: true,
+            StateSync: true,
+            StateSyncTransport: TransportIp,
+            DevicePriority: 100,
+            HeartbeatBackup: true,
+            Ha1Port: "ethernet1/5",
+            Ha1IpAddress: "10.3.1.1",
+            Ha1Netmask: "255.255.255.252",
+            Ha1Gateway: "10.3.1.2",
+            Ha1BackupPort: "management",
+            Ha2Port: "ethernet1/6",
+            Ha2IpAddress: "10.4.1.1",
+            Ha2Netmask: "255.255.255.252",
+            Ha2BackupPort: "ethernet1/7",
+            Ha2BackupIpAddress: "10.5.1.1",
+            Ha2BackupNetmask: "255.255.255.252",
+            Ha2BackupGateway: "10.5.1.2",
+            raw: map[string] string{
+                "timers": "<recommended/>",
+            },
+        }},
+        {"v2 active active with monitoring", version.Number{9, 0, 0, ""}, Config{
+            Enabled: true,
+            GroupId: 2,
+            PeerIp: "10.1.1.2",
+            Mode: ModeActiveActive,
+            DeviceId: 1,
+            Preemptive: true,
+            Ha3Port: "ethernet1/9",
+            LinkMonitoring: true,
+            LinkMonitoringFailureCondition: FailureConditionAny,
+            LinkGroups: []LinkGroup{
+                {"uplinks", true, FailureConditionAll, []string{"ethernet1/1", "ethernet1/2"}},
+                {"downlinks", false, FailureConditionAny, []string{"ethernet1/3"}},
+            },
+            PathMonitoring: true,
+            PathMonitoringFailureCondition: FailureConditionAll,
+            PathGroups: []PathGroup{
+                {PathGroupVirtualWire, "vw1", true, FailureConditionAny, "10.6.1.1", []DestinationGroup{
+                    {"dst1", true, FailureConditionAll, []string{"8.8.8.8", "8.8.4.4"}},
+                }},
+                {PathGroupVirtualRouter, "default", true, FailureConditionAny, "", []DestinationGroup{
+                    {"dst2", true, FailureConditionAny, []string{"1.1.1.1"}},
+                    {"dst3", false, FailureConditionAny, []string{"1.0.0.1"}},
+                }},
+            },
+        }},
+        {"v2 active active with unnormalized settings", version.Number{9, 0, 0, ""}, Config{
+            Enabled: true,
+            GroupId: 3,
+            PeerIp: "10.1.1.2",
+            Mode: ModeActiveActive,
+            DeviceId: 0,
+            StateSync: true,
+            Ha1Port: "ethernet1/5",
+            Ha3Port: "ethernet1/9",
+            PathMonitoring: true,
+            raw: map[string] string{
+                "timers": "<recommended/>",
+                "aanc": "<sync><virtual-router>yes</virtual-router><qos>no</qos></sync>",
+                "aava": "<entry name=\"ethernet1/1\"><ip><entry name=\"10.1.1.10/24\"><floating><device-priority><active-primary/></device-priority></floating></entry></ip></entry>",
+                "aasos": "<first-packet/>",
+                "aass": "<ip-modulo/>",
+                "ha2ka": "<enabled>yes</enabled><action>log-only</action><threshold>10000</threshold>",
+                "ha1enc": "<enabled>yes</enabled>",
+            },
+        }},
+    }
+}
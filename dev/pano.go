@@ -3,6 +3,10 @@ package dev
 import (
     "github.com/inwinstack/pango/util"
 
+    "github.com/inwinstack/pango/dev/certificate"
+    "github.com/inwinstack/pango/dev/certificate/certprof"
+    "github.com/inwinstack/pango/dev/certificate/ssltls"
+    "github.com/inwinstack/pango/dev/ha"
     "github.com/inwinstack/pango/dev/profile/email"
     emailsrv "github.com/inwinstack/pango/dev/profile/email/server"
     "github.com/inwinstack/pango/dev/profile/http"
@@ -14,32 +18,49 @@ import (
     "github.com/inwinstack/pango/dev/profile/snmp/v3"
     "github.com/inwinstack/pango/dev/profile/syslog"
     syslogsrv "github.com/inwinstack/pango/dev/profile/syslog/server"
+    "github.com/inwinstack/pango/dev/sharedgw"
+    "github.com/inwinstack/pango/dev/vsys"
 )
 
 
 // PanoDev is the client.Device namespace.
 type PanoDev struct {
+    Certificate *certificate.PanoCertificate
+    CertificateProfile *certprof.PanoCertProf
     EmailServer *emailsrv.PanoServer
     EmailServerProfile *email.PanoEmail
+    HaConfig *ha.PanoHa
     HttpHeader *header.PanoHeader
     HttpParam *param.PanoParam
     HttpServer *httpsrv.PanoServer
     HttpServerProfile *http.PanoHttp
+    SharedGateway *sharedgw.PanoSharedGw
     SnmpServerProfile *snmp.PanoSnmp
     SnmpV2cServer *v2c.PanoV2c
     SnmpV3Server *v3.PanoV3
+    SslTlsServiceProfile *ssltls.PanoSslTls
     SyslogServer *syslogsrv.PanoServer
     SyslogServerProfile *syslog.PanoSyslog
+    Vsys *vsys.PanoVsys
 }
 
 // Initialize is invoked on client.Initialize().
 func (c *PanoDev) Initialize(i util.XapiClient) {
+    c.Certificate = &certificate.PanoCertificate{}
+    c.Certificate.Initialize(i)
+
+    c.CertificateProfile = &certprof.PanoCertProf{}
+    c.CertificateProfile.Initialize(i)
+
     c.EmailServer = &emailsrv.PanoServer{}
     c.EmailServer.Initialize(i)
 
     c.EmailServerProfile = &email.PanoEmail{}
     c.EmailServerProfile.Initialize(i)
 
+    c.HaConfig = &ha.PanoHa{}
+    c.HaConfig.Initialize(i)
+
     c.HttpHeader = &header.PanoHeader{}
     c.HttpHeader.Initialize(i)
 
@@ -52,6 +73,9 @@ func (c *PanoDev) Initialize(i util.XapiClient) {
     c.HttpServerProfile = &http.PanoHttp{}
     c.HttpServerProfile.Initialize(i)
 
+    c.SharedGateway = &sharedgw.PanoSharedGw{}
+    c.SharedGateway.Initialize(i)
+
     c.SnmpServerProfile = &snmp.PanoSnmp{}
     c.SnmpServerProfile.Initialize(i)
 
@@ -61,9 +85,15 @@ func (c *PanoDev) Initialize(i util.XapiClient) {
     c.SnmpV3Server = &v3.PanoV3{}
     c.SnmpV3Server.Initialize(i)
 
+    c.SslTlsServiceProfile = &ssltls.PanoSslTls{}
+    c.SslTlsServiceProfile.Initialize(i)
+
     c.SyslogServer = &syslogsrv.PanoServer{}
     c.SyslogServer.Initialize(i)
 
     c.SyslogServerProfile = &syslog.PanoSyslog{}
     c.SyslogServerProfile.Initialize(i)
+
+    c.Vsys = &vsys.PanoVsys{}
+    c.Vsys.Initialize(i)
 }
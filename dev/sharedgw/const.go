@@ -0,0 +1,6 @@
+package sharedgw
+
+const (
+    singular = "shared gateway"
+    plural = "shared gateways"
+)
@@ -0,0 +1,11 @@
+/*
+Package sharedgw is the client.Device.SharedGateway namespace.
+
+A shared gateway is a special vsys that lets multiple vsys share an external
+interface.  As with vsys, only the shared gateway settings are normalized, so
+Edit only changes the display name and the imports of the shared gateway,
+leaving its zones, NAT policies, and other config untouched.
+
+Normalized object: Entry
+*/
+package sharedgw
@@ -0,0 +1,109 @@
+package sharedgw
+
+import (
+    "errors"
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a shared
+// gateway.
+type Entry struct {
+    Name string
+    DisplayName string
+    Interfaces []string
+    DnsProxy string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.DisplayName = s.DisplayName
+    o.Interfaces = s.Interfaces
+    o.DnsProxy = s.DnsProxy
+}
+
+/** Structs / functions for normalization. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        DisplayName: o.Answer.DisplayName,
+    }
+
+    if o.Answer.Import != nil {
+        ans.DnsProxy = o.Answer.Import.DnsProxy
+        ans.Interfaces = util.MemToStr(o.Answer.Import.Interfaces)
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    DisplayName string `xml:"display-name,omitempty"`
+    Import *imp `xml:"import"`
+}
+
+type imp struct {
+    XMLName xml.Name `xml:"import"`
+    DnsProxy string `xml:"dns-proxy,omitempty"`
+    Interfaces *util.MemberType `xml:"network>interface"`
+}
+
+func specify_v1(e Entry) interface{} {
+    return entry_v1{
+        Name: e.Name,
+        DisplayName: e.DisplayName,
+        Import: specifyImport(e),
+    }
+}
+
+func specifyImport(e Entry) *imp {
+    if e.DnsProxy == "" && len(e.Interfaces) == 0 {
+        return nil
+    }
+
+    return &imp{
+        DnsProxy: e.DnsProxy,
+        Interfaces: util.StrToMem(e.Interfaces),
+    }
+}
+
+// edit updates the display name and imports of the shared gateway at the given
+// xpath, creating it if it does not exist.  The rest of its config is left
+// as is.
+func edit(con util.XapiClient, path []string, e Entry) error {
+    sub := func(name string) []string {
+        return append(append(make([]string, 0, len(path) + 1), path...), name)
+    }
+
+    // Create the shared gateway if needed, along with its display name.
+    if _, err := con.Set(path[:len(path) - 1], entry_v1{Name: e.Name, DisplayName: e.DisplayName}, nil, nil); err != nil {
+        return err
+    }
+
+    if e.DisplayName == "" {
+        if _, err := con.Delete(sub("display-name"), nil, nil); err != nil && !errors.Is(err, util.ErrObjectNotFound) {
+            return err
+        }
+    }
+
+    i := specifyImport(e)
+    if i == nil {
+        i = &imp{}
+    }
+    _, err := con.Edit(sub("import"), i, nil, nil)
+    return err
+}
@@ -0,0 +1,20 @@
+package sharedgw
+
+type tc struct {
+    desc string
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"name only", Entry{
+            Name: "sg1",
+        }},
+        {"with imports", Entry{
+            Name: "sg1",
+            DisplayName: "Internet",
+            Interfaces: []string{"ethernet1/1", "ethernet1/2"},
+            DnsProxy: "proxy",
+        }},
+    }
+}
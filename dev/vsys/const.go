@@ -0,0 +1,6 @@
+package vsys
+
+const (
+    singular = "vsys"
+    plural = "vsys"
+)
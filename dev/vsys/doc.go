@@ -0,0 +1,11 @@
+/*
+Package vsys is the client.Device.Vsys namespace.
+
+A vsys entry holds all of the objects, policies, and other config of that
+virtual system.  Only the vsys settings are normalized, so Edit only changes
+the display name and the imports of the vsys, leaving everything else within
+it untouched.
+
+Normalized object: Entry
+*/
+package vsys
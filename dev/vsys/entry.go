@@ -0,0 +1,214 @@
+package vsys
+
+import (
+    "errors"
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a virtual
+// system.
+//
+// The Max* params are resource quotas for the vsys.  A value of 0 means that
+// the vsys is only limited by the platform limit.
+type Entry struct {
+    Name string
+    DisplayName string
+    Interfaces []string
+    VirtualRouters []string
+    Vlans []string
+    VirtualWires []string
+    VisibleVsys []string
+    DnsProxy string
+    MaxSessions int
+    MaxSiteToSiteVpnTunnels int
+    MaxConcurrentSslVpnTunnels int
+    MaxSecurityRules int
+    MaxNatRules int
+    MaxSslDecryptionRules int
+    MaxQosRules int
+    MaxApplicationOverrideRules int
+    MaxPbfRules int
+    MaxCpRules int
+    MaxDosRules int
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.DisplayName = s.DisplayName
+    o.Interfaces = s.Interfaces
+    o.VirtualRouters = s.VirtualRouters
+    o.Vlans = s.Vlans
+    o.VirtualWires = s.VirtualWires
+    o.VisibleVsys = s.VisibleVsys
+    o.DnsProxy = s.DnsProxy
+    o.MaxSessions = s.MaxSessions
+    o.MaxSiteToSiteVpnTunnels = s.MaxSiteToSiteVpnTunnels
+    o.MaxConcurrentSslVpnTunnels = s.MaxConcurrentSslVpnTunnels
+    o.MaxSecurityRules = s.MaxSecurityRules
+    o.MaxNatRules = s.MaxNatRules
+    o.MaxSslDecryptionRules = s.MaxSslDecryptionRules
+    o.MaxQosRules = s.MaxQosRules
+    o.MaxApplicationOverrideRules = s.MaxApplicationOverrideRules
+    o.MaxPbfRules = s.MaxPbfRules
+    o.MaxCpRules = s.MaxCpRules
+    o.MaxDosRules = s.MaxDosRules
+}
+
+/** Structs / functions for normalization. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        DisplayName: o.Answer.DisplayName,
+    }
+
+    if o.Answer.Import != nil {
+        ans.VisibleVsys = util.MemToStr(o.Answer.Import.VisibleVsys)
+        ans.DnsProxy = o.Answer.Import.DnsProxy
+        if o.Answer.Import.Network != nil {
+            ans.Interfaces = util.MemToStr(o.Answer.Import.Network.Interfaces)
+            ans.VirtualRouters = util.MemToStr(o.Answer.Import.Network.VirtualRouters)
+            ans.Vlans = util.MemToStr(o.Answer.Import.Network.Vlans)
+            ans.VirtualWires = util.MemToStr(o.Answer.Import.Network.VirtualWires)
+        }
+        if r := o.Answer.Import.Resource; r != nil {
+            ans.MaxSessions = r.MaxSessions
+            ans.MaxSiteToSiteVpnTunnels = r.MaxSiteToSiteVpnTunnels
+            ans.MaxConcurrentSslVpnTunnels = r.MaxConcurrentSslVpnTunnels
+            ans.MaxSecurityRules = r.MaxSecurityRules
+            ans.MaxNatRules = r.MaxNatRules
+            ans.MaxSslDecryptionRules = r.MaxSslDecryptionRules
+            ans.MaxQosRules = r.MaxQosRules
+            ans.MaxApplicationOverrideRules = r.MaxApplicationOverrideRules
+            ans.MaxPbfRules = r.MaxPbfRules
+            ans.MaxCpRules = r.MaxCpRules
+            ans.MaxDosRules = r.MaxDosRules
+        }
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    DisplayName string `xml:"display-name,omitempty"`
+    Import *imp `xml:"import"`
+}
+
+type imp struct {
+    XMLName xml.Name `xml:"import"`
+    Network *impNetwork `xml:"network"`
+    VisibleVsys *util.MemberType `xml:"visible-vsys"`
+    DnsProxy string `xml:"dns-proxy,omitempty"`
+    Resource *resource `xml:"resource"`
+}
+
+type impNetwork struct {
+    Interfaces *util.MemberType `xml:"interface"`
+    VirtualRouters *util.MemberType `xml:"virtual-router"`
+    Vlans *util.MemberType `xml:"vlan"`
+    VirtualWires *util.MemberType `xml:"virtual-wire"`
+}
+
+type resource struct {
+    MaxSessions int `xml:"max-sessions,omitempty"`
+    MaxSiteToSiteVpnTunnels int `xml:"max-site-to-site-vpn-tunnels,omitempty"`
+    MaxConcurrentSslVpnTunnels int `xml:"max-concurrent-ssl-vpn-tunnels,omitempty"`
+    MaxSecurityRules int `xml:"max-security-rules,omitempty"`
+    MaxNatRules int `xml:"max-nat-rules,omitempty"`
+    MaxSslDecryptionRules int `xml:"max-ssl-decryption-rules,omitempty"`
+    MaxQosRules int `xml:"max-qos-rules,omitempty"`
+    MaxApplicationOverrideRules int `xml:"max-application-override-rules,omitempty"`
+    MaxPbfRules int `xml:"max-pbf-rules,omitempty"`
+    MaxCpRules int `xml:"max-cp-rules,omitempty"`
+    MaxDosRules int `xml:"max-dos-rules,omitempty"`
+}
+
+func specify_v1(e Entry) interface{} {
+    return entry_v1{
+        Name: e.Name,
+        DisplayName: e.DisplayName,
+        Import: specifyImport(e),
+    }
+}
+
+func specifyImport(e Entry) *imp {
+    i := imp{
+        VisibleVsys: util.StrToMem(e.VisibleVsys),
+        DnsProxy: e.DnsProxy,
+    }
+    hasImport := i.VisibleVsys != nil || i.DnsProxy != ""
+
+    if len(e.Interfaces) > 0 || len(e.VirtualRouters) > 0 || len(e.Vlans) > 0 || len(e.VirtualWires) > 0 {
+        hasImport = true
+        i.Network = &impNetwork{
+            Interfaces: util.StrToMem(e.Interfaces),
+            VirtualRouters: util.StrToMem(e.VirtualRouters),
+            Vlans: util.StrToMem(e.Vlans),
+            VirtualWires: util.StrToMem(e.VirtualWires),
+        }
+    }
+
+    r := resource{
+        MaxSessions: e.MaxSessions,
+        MaxSiteToSiteVpnTunnels: e.MaxSiteToSiteVpnTunnels,
+        MaxConcurrentSslVpnTunnels: e.MaxConcurrentSslVpnTunnels,
+        MaxSecurityRules: e.MaxSecurityRules,
+        MaxNatRules: e.MaxNatRules,
+        MaxSslDecryptionRules: e.MaxSslDecryptionRules,
+        MaxQosRules: e.MaxQosRules,
+        MaxApplicationOverrideRules: e.MaxApplicationOverrideRules,
+        MaxPbfRules: e.MaxPbfRules,
+        MaxCpRules: e.MaxCpRules,
+        MaxDosRules: e.MaxDosRules,
+    }
+    if r != (resource{}) {
+        hasImport = true
+        i.Resource = &r
+    }
+
+    if !hasImport {
+        return nil
+    }
+    return &i
+}
+
+// edit updates the display name and imports of the virtual system at the given
+// xpath, creating it if it does not exist.  The rest of its config is left
+// as is.
+func edit(con util.XapiClient, path []string, e Entry) error {
+    sub := func(name string) []string {
+        return append(append(make([]string, 0, len(path) + 1), path...), name)
+    }
+
+    // Create the virtual system if needed, along with its display name.
+    if _, err := con.Set(path[:len(path) - 1], entry_v1{Name: e.Name, DisplayName: e.DisplayName}, nil, nil); err != nil {
+        return err
+    }
+
+    if e.DisplayName == "" {
+        if _, err := con.Delete(sub("display-name"), nil, nil); err != nil && !errors.Is(err, util.ErrObjectNotFound) {
+            return err
+        }
+    }
+
+    i := specifyImport(e)
+    if i == nil {
+        i = &imp{}
+    }
+    _, err := con.Edit(sub("import"), i, nil, nil)
+    return err
+}
@@ -0,0 +1,92 @@
+package vsys
+
+import (
+    "testing"
+    "reflect"
+    "strings"
+
+    "github.com/inwinstack/pango/testdata"
+    "github.com/inwinstack/pango/util"
+)
+
+
+func TestFwNormalization(t *testing.T) {
+    testCases := getTests()
+
+    mc := &testdata.MockClient{}
+    ns := &FwVsys{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            mc.AddResp("")
+            err := ns.Set(tc.conf)
+            if err != nil {
+                t.Errorf("Error in set: %s", err)
+            } else {
+                mc.AddResp(mc.Elm)
+                r, err := ns.Get(tc.conf.Name)
+                if err != nil {
+                    t.Errorf("Error in get: %s", err)
+                } else if !reflect.DeepEqual(tc.conf, r) {
+                    t.Errorf("%#v != %#v", tc.conf, r)
+                }
+            }
+        })
+    }
+}
+
+type notFoundError struct{}
+
+func (e notFoundError) Error() string {
+    return "Object doesn't exist"
+}
+
+func (e notFoundError) Is(target error) bool {
+    return target == util.ErrObjectNotFound
+}
+
+func TestFwEditOnlySettings(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &FwVsys{}
+    ns.Initialize(mc)
+
+    mc.AddResp("")
+    mc.AddResp("")
+    if err := ns.Edit(Entry{Name: "vsys2", DisplayName: "new", DnsProxy: "proxy"}); err != nil {
+        t.Fatalf("Error in edit: %s", err)
+    }
+    if mc.Called != 2 {
+        t.Errorf("Expected a set then an edit, got %d calls", mc.Called)
+    }
+    if mc.Function != "edit" {
+        t.Errorf("Function is %q, not edit", mc.Function)
+    }
+    if !strings.HasSuffix(mc.Path, "/entry[@name='vsys2']/import") {
+        t.Errorf("Edit was not limited to the imports: %s", mc.Path)
+    }
+    if mc.Elm != "<import><dns-proxy>proxy</dns-proxy></import>" {
+        t.Errorf("Unexpected import: %s", mc.Elm)
+    }
+}
+
+func TestFwEditNoDisplayName(t *testing.T) {
+    mc := &testdata.MockClient{Resp: []testdata.Response{
+        {[]byte("<response><result /></response>"), nil},
+        {[]byte("<response status=\"error\" code=\"7\"/>"), notFoundError{}},
+        {[]byte("<response><result /></response>"), nil},
+    }}
+    ns := &FwVsys{}
+    ns.Initialize(mc)
+
+    if err := ns.Edit(Entry{Name: "vsys2"}); err != nil {
+        t.Fatalf("Error in edit: %s", err)
+    }
+    if mc.Called != 3 {
+        t.Errorf("Expected a set, delete, and edit, got %d calls", mc.Called)
+    }
+    if mc.Elm != "<import></import>" {
+        t.Errorf("Imports were not cleared: %s", mc.Elm)
+    }
+}
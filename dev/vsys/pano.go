@@ -0,0 +1,173 @@
+package vsys
+
+import (
+    "fmt"
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// PanoVsys is the client.Device.Vsys namespace.
+type PanoVsys struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked by client.Initialize().
+func (c *PanoVsys) Initialize(con util.XapiClient) {
+    c.con = con
+}
+
+// ShowList performs SHOW to retrieve a list of virtual systems.
+func (c *PanoVsys) ShowList(tmpl, ts string) ([]string, error) {
+    if tmpl == "" && ts == "" {
+        return nil, fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    c.con.LogQuery("(show) list of %s", plural)
+    path := c.xpath(tmpl, ts, nil)
+    return c.con.EntryListUsing(c.con.Show, path[:len(path) - 1])
+}
+
+// GetList performs GET to retrieve a list of virtual systems.
+func (c *PanoVsys) GetList(tmpl, ts string) ([]string, error) {
+    if tmpl == "" && ts == "" {
+        return nil, fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    c.con.LogQuery("(get) list of %s", plural)
+    path := c.xpath(tmpl, ts, nil)
+    return c.con.EntryListUsing(c.con.Get, path[:len(path) - 1])
+}
+
+// Get performs GET to retrieve information for the given virtual system.
+func (c *PanoVsys) Get(tmpl, ts, name string) (Entry, error) {
+    c.con.LogQuery("(get) %s %q", singular, name)
+    return c.details(c.con.Get, tmpl, ts, name)
+}
+
+// Show performs SHOW to retrieve information for the given virtual system.
+func (c *PanoVsys) Show(tmpl, ts, name string) (Entry, error) {
+    c.con.LogQuery("(show) %s %q", singular, name)
+    return c.details(c.con.Show, tmpl, ts, name)
+}
+
+// Set performs SET to create / update one or more virtual systems.
+func (c *PanoVsys) Set(tmpl, ts string, e ...Entry) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+    if tmpl == "" && ts == "" {
+        return fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    _, fn := c.versioning()
+    names := make([]string, len(e))
+
+    // Build up the struct.
+    d := util.BulkElement{XMLName: xml.Name{Local: "vsys"}}
+    for i := range e {
+        d.Data = append(d.Data, fn(e[i]))
+        names[i] = e[i].Name
+    }
+    c.con.LogAction("(set) %s: %v", plural, names)
+
+    // Set xpath.
+    path := c.xpath(tmpl, ts, names)
+    if len(e) == 1 {
+        path = path[:len(path) - 1]
+    } else {
+        path = path[:len(path) - 2]
+    }
+
+    // Create the objects.
+    _, err = c.con.Set(path, d.Config(), nil, nil)
+    return err
+}
+
+// Edit performs EDIT to create / update one virtual system.
+//
+// Only the display name and the imports are edited, leaving the rest of the
+// virtual system's config as is.
+func (c *PanoVsys) Edit(tmpl, ts string, e Entry) error {
+    if tmpl == "" && ts == "" {
+        return fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    c.con.LogAction("(edit) %s %q", singular, e.Name)
+
+    // Set xpath.
+    path := c.xpath(tmpl, ts, []string{e.Name})
+
+    // Edit the object.
+    return edit(c.con, path, e)
+}
+
+// Delete removes the given virtual systems.
+//
+// Virtual systems can be a string or an Entry object.
+func (c *PanoVsys) Delete(tmpl, ts string, e ...interface{}) error {
+    var err error
+
+    if len(e) == 0 {
+        return nil
+    }
+    if tmpl == "" && ts == "" {
+        return fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    names := make([]string, len(e))
+    for i := range e {
+        switch v := e[i].(type) {
+        case string:
+            names[i] = v
+        case Entry:
+            names[i] = v.Name
+        default:
+            return fmt.Errorf("Unknown type sent to delete: %s", v)
+        }
+    }
+    c.con.LogAction("(delete) %s: %v", plural, names)
+
+    // Remove the objects.
+    path := c.xpath(tmpl, ts, names)
+    _, err = c.con.Delete(path, nil, nil)
+    return err
+}
+
+/** Internal functions for this namespace struct **/
+
+func (c *PanoVsys) versioning() (normalizer, func(Entry) (interface{})) {
+    return &container_v1{}, specify_v1
+}
+
+func (c *PanoVsys) details(fn util.Retriever, tmpl, ts, name string) (Entry, error) {
+    if tmpl == "" && ts == "" {
+        return Entry{}, fmt.Errorf("tmpl or ts must be specified")
+    }
+
+    path := c.xpath(tmpl, ts, []string{name})
+    obj, _ := c.versioning()
+    if _, err := fn(path, nil, obj); err != nil {
+        return Entry{}, err
+    }
+    ans := obj.Normalize()
+
+    return ans, nil
+}
+
+func (c *PanoVsys) xpath(tmpl, ts string, vals []string) []string {
+    ans := make([]string, 0, 10)
+    ans = append(ans, util.TemplateXpathPrefix(tmpl, ts)...)
+    ans = append(ans,
+        "config",
+        "devices",
+        util.AsEntryXpath([]string{"localhost.localdomain"}),
+        "vsys",
+        util.AsEntryXpath(vals),
+    )
+
+    return ans
+}
@@ -0,0 +1,38 @@
+package vsys
+
+type tc struct {
+    desc string
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"name only", Entry{
+            Name: "vsys2",
+        }},
+        {"with display name and imports", Entry{
+            Name: "vsys2",
+            DisplayName: "Tenant A",
+            Interfaces: []string{"ethernet1/1", "ethernet1/2"},
+            VirtualRouters: []string{"vr-a"},
+            Vlans: []string{"vlan-a"},
+            VirtualWires: []string{"vw-a"},
+            VisibleVsys: []string{"vsys1", "vsys3"},
+            DnsProxy: "proxy-a",
+        }},
+        {"with resources", Entry{
+            Name: "vsys3",
+            MaxSessions: 50000,
+            MaxSiteToSiteVpnTunnels: 10,
+            MaxConcurrentSslVpnTunnels: 20,
+            MaxSecurityRules: 500,
+            MaxNatRules: 100,
+            MaxSslDecryptionRules: 50,
+            MaxQosRules: 40,
+            MaxApplicationOverrideRules: 30,
+            MaxPbfRules: 20,
+            MaxCpRules: 10,
+            MaxDosRules: 5,
+        }},
+    }
+}
@@ -0,0 +1,351 @@
+package pango
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/inwinstack/pango/util"
+)
+
+// Config diff actions.
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+	DiffMoved    = "moved"
+)
+
+// ConfigChange is a single difference between the running config and the
+// candidate config.
+type ConfigChange struct {
+	Action    string
+	Xpath     string
+	Running   string
+	Candidate string
+}
+
+// ConfigDiff is the set of differences between the running config and the
+// candidate config, keyed by xpath.
+//
+// Added and removed objects are reported once at the top most xpath that
+// was added or removed.  Modifications are reported at the deepest xpath
+// whose value changed; lists of members are treated as a single value.
+//
+// Entries of ordered lists (the rules of a rulebase) whose position changed
+// relative to the other entries are reported as moved, even if the entry
+// itself was also modified.  Changes beneath a moved entry are still reported
+// at their own xpaths.
+type ConfigDiff map[string]ConfigChange
+
+// Xpaths returns the xpaths of all changes, sorted.
+func (d ConfigDiff) Xpaths() []string {
+	ans := make([]string, 0, len(d))
+	for k := range d {
+		ans = append(ans, k)
+	}
+	sort.Strings(ans)
+	return ans
+}
+
+// Filter returns only the changes with the given action.
+func (d ConfigDiff) Filter(action string) ConfigDiff {
+	ans := make(ConfigDiff)
+	for k, v := range d {
+		if v.Action == action {
+			ans[k] = v
+		}
+	}
+	return ans
+}
+
+// String returns a human readable summary of the changes, one per line,
+// prefixed with "+" for added, "-" for removed, "~" for modified, and ">"
+// for moved.
+func (d ConfigDiff) String() string {
+	var buf bytes.Buffer
+	for _, k := range d.Xpaths() {
+		switch d[k].Action {
+		case DiffAdded:
+			buf.WriteString("+ ")
+		case DiffRemoved:
+			buf.WriteString("- ")
+		case DiffMoved:
+			buf.WriteString("> ")
+		default:
+			buf.WriteString("~ ")
+		}
+		buf.WriteString(k)
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// DiffConfig returns the differences between the running config and the
+// candidate config beneath the given xpath, that is, what a commit would
+// change.  If path is empty, then the entire config is compared.
+//
+// Both configs are retrieved using Show() and Get(), so the path must
+// select a single node.
+func (c *Client) DiffConfig(path []string) (ConfigDiff, error) {
+	return c.DiffConfigContext(c.Context(), path)
+}
+
+// DiffConfigContext is DiffConfig, but bound to the given context.
+func (c *Client) DiffConfigContext(ctx context.Context, path []string) (ConfigDiff, error) {
+	if len(path) == 0 {
+		path = []string{"config"}
+	}
+	base := util.AsXpath(path[:len(path)-1])
+	if base == "/" {
+		base = ""
+	}
+
+	c.LogAction("(diff) running and candidate config: %s", util.AsXpath(path))
+
+	running, err := c.diffRetrieve(ctx, c.ShowContext, path)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := c.diffRetrieve(ctx, c.GetContext, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make(ConfigDiff)
+	ans.children(base, running, candidate)
+	return ans, nil
+}
+
+func (c *Client) diffRetrieve(ctx context.Context, fn func(context.Context, interface{}, interface{}, interface{}) ([]byte, error), path []string) ([]*diffNode, error) {
+	var ans diffResult
+
+	_, err := fn(ctx, path, nil, &ans)
+	if err != nil {
+		var e PanosError
+		if errors.As(err, &e) && e.ObjectNotFound() {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, n := range ans.Result.Children {
+		n.clean()
+	}
+	return ans.Result.Children, nil
+}
+
+// children compares the children of a node at the given xpath.
+func (d ConfigDiff) children(xpath string, running, candidate []*diffNode) {
+	seen := make(map[string]bool, len(candidate))
+	for _, cn := range candidate {
+		key := cn.step()
+		seen[key] = true
+		rn := diffFind(running, key)
+		if rn == nil {
+			d.add(DiffAdded, xpath+"/"+key, nil, cn)
+		} else {
+			d.compare(xpath+"/"+key, rn, cn)
+		}
+	}
+
+	for _, rn := range running {
+		if key := rn.step(); !seen[key] {
+			d.add(DiffRemoved, xpath+"/"+key, rn, nil)
+		}
+	}
+
+	if strings.HasSuffix(xpath, "/rules") {
+		d.moves(xpath, running, candidate)
+	}
+}
+
+// moves reports the entries of an ordered list whose position changed.
+//
+// Only entries present in both configs are considered, and the entries
+// that keep their relative order (the longest common subsequence) are not
+// moved, so moving a single rule reports only that rule.
+func (d ConfigDiff) moves(xpath string, running, candidate []*diffNode) {
+	rk := diffCommonKeys(running, candidate)
+	ck := diffCommonKeys(candidate, running)
+
+	// Longest common subsequence of the two orderings.
+	lcs := make([][]int, len(rk)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(ck)+1)
+	}
+	for i := len(rk) - 1; i >= 0; i-- {
+		for j := len(ck) - 1; j >= 0; j-- {
+			if rk[i] == ck[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	kept := make(map[string]bool, len(rk))
+	for i, j := 0, 0; i < len(rk) && j < len(ck); {
+		switch {
+		case rk[i] == ck[j]:
+			kept[rk[i]] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	// A change to the entry's own attributes is replaced by the move, which
+	// carries both versions of the entry.
+	for _, key := range ck {
+		if !kept[key] {
+			d.add(DiffMoved, xpath+"/"+key, diffFind(running, key), diffFind(candidate, key))
+		}
+	}
+}
+
+// compare compares the given node as it exists in both configs.
+func (d ConfigDiff) compare(xpath string, running, candidate *diffNode) {
+	if running.isValue() || candidate.isValue() {
+		if running.String() != candidate.String() {
+			d.add(DiffModified, xpath, running, candidate)
+		}
+		return
+	}
+
+	if running.attrString() != candidate.attrString() {
+		d.add(DiffModified, xpath, running, candidate)
+		return
+	}
+
+	d.children(xpath, running.Children, candidate.Children)
+}
+
+func (d ConfigDiff) add(action, xpath string, running, candidate *diffNode) {
+	ch := ConfigChange{Action: action, Xpath: xpath}
+	if running != nil {
+		ch.Running = running.String()
+	}
+	if candidate != nil {
+		ch.Candidate = candidate.String()
+	}
+	d[xpath] = ch
+}
+
+/** Internal structs **/
+
+type diffResult struct {
+	XMLName xml.Name `xml:"response"`
+	Result  struct {
+		Children []*diffNode `xml:",any"`
+	} `xml:"result"`
+}
+
+type diffNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr  `xml:",any,attr"`
+	Text     string      `xml:",chardata"`
+	Children []*diffNode `xml:",any"`
+}
+
+// diffIgnoredAttrs are the attributes PAN-OS adds to candidate config nodes
+// to track uncommitted changes.
+var diffIgnoredAttrs = map[string]bool{
+	"admin":   true,
+	"dirtyId": true,
+	"time":    true,
+}
+
+// clean removes bookkeeping attributes and insignificant whitespace.
+func (n *diffNode) clean() {
+	attrs := n.Attrs[:0]
+	for _, a := range n.Attrs {
+		if !diffIgnoredAttrs[a.Name.Local] {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: a.Name.Local}, Value: a.Value})
+		}
+	}
+	n.Attrs = attrs
+	n.XMLName.Space = ""
+
+	if len(n.Children) > 0 || strings.TrimSpace(n.Text) == "" {
+		n.Text = ""
+	}
+	for _, c := range n.Children {
+		c.clean()
+	}
+}
+
+func (n *diffNode) attr(key string) (string, bool) {
+	for _, a := range n.Attrs {
+		if a.Name.Local == key {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// step returns the xpath step that selects this node from its parent.
+func (n *diffNode) step() string {
+	if name, ok := n.attr("name"); ok {
+		return fmt.Sprintf("%s[@name='%s']", n.XMLName.Local, name)
+	}
+	return n.XMLName.Local
+}
+
+// isValue returns true if this node holds a value instead of other config
+// nodes: either it has text, or its children are all members.
+func (n *diffNode) isValue() bool {
+	if len(n.Children) == 0 {
+		return n.Text != ""
+	}
+	for _, c := range n.Children {
+		if c.XMLName.Local != "member" {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *diffNode) attrString() string {
+	var buf bytes.Buffer
+	for _, a := range n.Attrs {
+		fmt.Fprintf(&buf, " %s=%q", a.Name.Local, a.Value)
+	}
+	return buf.String()
+}
+
+// String returns this node as XML.
+func (n *diffNode) String() string {
+	b, _ := xml.Marshal(n)
+	return string(b)
+}
+
+// diffCommonKeys returns the steps of the nodes in list that are also in
+// other, in the order they appear in list.
+func diffCommonKeys(list, other []*diffNode) []string {
+	ans := make([]string, 0, len(list))
+	for _, n := range list {
+		if key := n.step(); diffFind(other, key) != nil {
+			ans = append(ans, key)
+		}
+	}
+	return ans
+}
+
+func diffFind(list []*diffNode, key string) *diffNode {
+	for _, n := range list {
+		if n.step() == key {
+			return n
+		}
+	}
+	return nil
+}
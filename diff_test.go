@@ -0,0 +1,178 @@
+package pango
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfig(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success"><result><vsys><entry name="vsys1">
+            <address>
+                <entry name="same"><ip-netmask>10.1.1.1</ip-netmask></entry>
+                <entry name="changed"><ip-netmask>10.2.2.2</ip-netmask><tag><member>a</member></tag></entry>
+                <entry name="gone"><fqdn>example.com</fqdn></entry>
+            </address>
+        </entry></vsys></result></response>`),
+		[]byte(`<response status="success" code="19"><result total-count="1" count="1"><vsys admin="admin" dirtyId="2" time="2020/01/01 00:00:00"><entry name="vsys1">
+            <address>
+                <entry name="same"><ip-netmask>10.1.1.1</ip-netmask></entry>
+                <entry name="changed" admin="admin" dirtyId="2" time="2020/01/01 00:00:00"><ip-netmask>10.2.2.2</ip-netmask><tag><member>a</member><member>b</member></tag></entry>
+                <entry name="new"><fqdn>example.org</fqdn></entry>
+            </address>
+            <zone/>
+        </entry></vsys></result></response>`),
+	})
+
+	d, err := c.DiffConfig([]string{"config", "devices", "entry[@name='localhost.localdomain']", "vsys"})
+	if err != nil {
+		t.Fatalf("Error in diff: %s", err)
+	}
+
+	if c.rp[0].Get("action") != "show" || c.rp[1].Get("action") != "get" {
+		t.Errorf("Unexpected actions: %s %s", c.rp[0].Get("action"), c.rp[1].Get("action"))
+	}
+
+	pre := "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']"
+	expected := []string{
+		pre + "/address/entry[@name='changed']/tag",
+		pre + "/address/entry[@name='gone']",
+		pre + "/address/entry[@name='new']",
+		pre + "/zone",
+	}
+	if !reflect.DeepEqual(d.Xpaths(), expected) {
+		t.Fatalf("Unexpected xpaths:\n%s", d)
+	}
+
+	if ch := d[expected[0]]; ch.Action != DiffModified || ch.Running != "<tag><member>a</member></tag>" || ch.Candidate != "<tag><member>a</member><member>b</member></tag>" {
+		t.Errorf("Unexpected modification: %#v", ch)
+	}
+	if ch := d[expected[1]]; ch.Action != DiffRemoved || ch.Running != "<entry name=\"gone\"><fqdn>example.com</fqdn></entry>" || ch.Candidate != "" {
+		t.Errorf("Unexpected removal: %#v", ch)
+	}
+	if ch := d[expected[2]]; ch.Action != DiffAdded || ch.Running != "" {
+		t.Errorf("Unexpected addition: %#v", ch)
+	}
+	if len(d.Filter(DiffAdded)) != 2 {
+		t.Errorf("Expected 2 additions, got %d", len(d.Filter(DiffAdded)))
+	}
+}
+
+func TestDiffConfigNoChanges(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success"><result><shared><address><entry name="a"><fqdn>x</fqdn></entry></address></shared></result></response>`),
+	})
+
+	d, err := c.DiffConfig([]string{"config", "shared"})
+	if err != nil {
+		t.Fatalf("Error in diff: %s", err)
+	} else if len(d) != 0 || d.String() != "" {
+		t.Errorf("Unexpected changes:\n%s", d)
+	}
+}
+
+func TestDiffConfigMovedRule(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success"><result><rulebase><security><rules>
+            <entry name="r1"><action>allow</action></entry>
+            <entry name="r2"><action>allow</action></entry>
+            <entry name="r3"><action>deny</action></entry>
+        </rules></security></rulebase></result></response>`),
+		[]byte(`<response status="success"><result><rulebase><security><rules>
+            <entry name="r3"><action>deny</action></entry>
+            <entry name="r1"><action>allow</action></entry>
+            <entry name="r2"><action>drop</action></entry>
+        </rules></security></rulebase></result></response>`),
+	})
+
+	d, err := c.DiffConfig([]string{"config", "devices", "entry[@name='localhost.localdomain']", "vsys", "entry[@name='vsys1']", "rulebase"})
+	if err != nil {
+		t.Fatalf("Error in diff: %s", err)
+	}
+
+	pre := "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/rulebase/security/rules"
+	expected := []string{
+		pre + "/entry[@name='r2']/action",
+		pre + "/entry[@name='r3']",
+	}
+	if !reflect.DeepEqual(d.Xpaths(), expected) {
+		t.Fatalf("Unexpected xpaths:\n%s", d)
+	}
+	if ch := d[expected[1]]; ch.Action != DiffMoved || ch.Running != ch.Candidate {
+		t.Errorf("Unexpected move: %#v", ch)
+	}
+	if d.String() != "~ "+expected[0]+"\n> "+expected[1]+"\n" {
+		t.Errorf("Unexpected summary:\n%s", d)
+	}
+}
+
+func TestDiffConfigMovedAndModifiedRule(t *testing.T) {
+	running := []byte(`<response status="success"><result><rules>
+        <entry name="r1" uuid="1"><action>allow</action></entry>
+        <entry name="r2" uuid="2"><action>allow</action></entry>
+        <entry name="r3" uuid="3"><action>deny</action></entry>
+    </rules></result></response>`)
+	pre := "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/rulebase/security/rules"
+
+	testCases := []struct {
+		desc      string
+		candidate string
+		expected  map[string]string
+	}{
+		{"child modified", `<entry name="r3" uuid="3"><action>drop</action></entry>
+            <entry name="r1" uuid="1"><action>allow</action></entry>
+            <entry name="r2" uuid="2"><action>allow</action></entry>`, map[string]string{
+			pre + "/entry[@name='r3']":        DiffMoved,
+			pre + "/entry[@name='r3']/action": DiffModified,
+		}},
+		{"attribute modified", `<entry name="r3" uuid="4"><action>deny</action></entry>
+            <entry name="r1" uuid="1"><action>allow</action></entry>
+            <entry name="r2" uuid="2"><action>allow</action></entry>`, map[string]string{
+			pre + "/entry[@name='r3']": DiffMoved,
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &Client{Logging: LogQuiet}
+			c.setResponses([][]byte{
+				running,
+				[]byte(`<response status="success"><result><rules>` + tc.candidate + `</rules></result></response>`),
+			})
+
+			d, err := c.DiffConfig([]string{"config", "devices", "entry[@name='localhost.localdomain']", "vsys", "entry[@name='vsys1']", "rulebase", "security", "rules"})
+			if err != nil {
+				t.Fatalf("Error in diff: %s", err)
+			}
+			if len(d) != len(tc.expected) {
+				t.Fatalf("Unexpected changes:\n%s", d)
+			}
+			for k, action := range tc.expected {
+				if d[k].Action != action {
+					t.Errorf("Expected %s to be %s, got %q", k, action, d[k].Action)
+				}
+			}
+			if ch := d[pre+"/entry[@name='r3']"]; ch.Running == ch.Candidate {
+				t.Errorf("Move does not show the modified entry: %#v", ch)
+			}
+		})
+	}
+}
+
+func TestDiffConfigUnorderedEntries(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success"><result><address><entry name="a"><fqdn>x</fqdn></entry><entry name="b"><fqdn>y</fqdn></entry></address></result></response>`),
+		[]byte(`<response status="success"><result><address><entry name="b"><fqdn>y</fqdn></entry><entry name="a"><fqdn>x</fqdn></entry></address></result></response>`),
+	})
+
+	d, err := c.DiffConfig([]string{"config", "shared", "address"})
+	if err != nil {
+		t.Fatalf("Error in diff: %s", err)
+	} else if len(d) != 0 {
+		t.Errorf("Unexpected changes:\n%s", d)
+	}
+}
@@ -71,6 +71,18 @@ either a Get() or a Show() first, make your modification, then invoke
 Edit() using that object.  If you don't do this, you will truncate any sub
 config.
 
+Using Contexts
+
+Every API call made by the client can be bound to a context.Context, allowing
+callers to cancel a hung call or put a deadline on a series of calls.  The
+Client has context aware variants of its base functions (OpContext,
+ShowContext, SetContext, WaitForJobContext, etc), and WithContext() returns a
+copy of the client with all namespaces bound to the given context:
+
+    ctx, cancel := context.WithTimeout(context.Background(), 30 * time.Second)
+    defer cancel()
+    err = c.WithContext(ctx).Objects.Address.Set("vsys1", addr.Entry{...})
+
 To learn more about PAN-OS XML API, please refer to the Palo Alto Netowrks
 API documentation.
 */
@@ -0,0 +1,130 @@
+package pango
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/inwinstack/pango/util"
+)
+
+// These are the sentinel errors that a PanosError can be matched against
+// using errors.Is:
+//
+//      if errors.Is(err, pango.ErrConfigLocked) {
+//          ...
+//      }
+//
+// The underlying PanosError, with the response code and message details, can
+// be retrieved using errors.As.
+var (
+	ErrObjectNotFound     = util.ErrObjectNotFound
+	ErrObjectExists       = errors.New("object already exists")
+	ErrReferenceInUse     = errors.New("object is referenced")
+	ErrInvalidXpath       = errors.New("invalid xpath")
+	ErrAuthFailure        = errors.New("authentication failure")
+	ErrSessionTimeout     = errors.New("session timed out")
+	ErrCommitLocked       = errors.New("commit is locked")
+	ErrCommitInProgress   = errors.New("commit is in progress")
+	ErrConfigLocked       = errors.New("config is locked")
+	ErrUnsupportedVersion = util.ErrUnsupportedVersion
+)
+
+// Is allows PanosError to be matched against the sentinel errors with
+// errors.Is.
+func (e PanosError) Is(target error) bool {
+	switch target {
+	case ErrObjectNotFound:
+		return e.ObjectNotFound()
+	case ErrObjectExists:
+		return e.ObjectExists()
+	case ErrReferenceInUse:
+		return e.ReferenceInUse()
+	case ErrInvalidXpath:
+		return e.InvalidXpath()
+	case ErrAuthFailure:
+		return e.AuthFailure()
+	case ErrSessionTimeout:
+		return e.SessionTimeout()
+	case ErrCommitLocked:
+		return e.CommitLocked()
+	case ErrCommitInProgress:
+		return e.CommitInProgress()
+	case ErrConfigLocked:
+		return e.ConfigLocked()
+	case ErrUnsupportedVersion:
+		return e.UnsupportedVersion()
+	}
+
+	return false
+}
+
+// ObjectExists returns true if the object being created already exists.
+func (e PanosError) ObjectExists() bool {
+	return e.Code == 8 || e.contains("already exists")
+}
+
+// ReferenceInUse returns true if the object can't be removed because it is
+// still referenced by other config.
+func (e PanosError) ReferenceInUse() bool {
+	return e.Code == 10 || e.contains("is referenced", "cannot be deleted because of references", "reference count not zero")
+}
+
+// InvalidXpath returns true if the xpath sent to PAN-OS was invalid.
+func (e PanosError) InvalidXpath() bool {
+	return e.Code == 6
+}
+
+// AuthFailure returns true if the credentials or API key were rejected.
+func (e PanosError) AuthFailure() bool {
+	return e.Code == 16 || e.Code == 403 || e.contains("invalid credential")
+}
+
+// SessionTimeout returns true if the API session has timed out.
+func (e PanosError) SessionTimeout() bool {
+	return e.Code == 22
+}
+
+// CommitLocked returns true if a commit lock is held by another administrator.
+func (e PanosError) CommitLocked() bool {
+	return e.contains("commit lock", "commit is currently locked", "commit is locked")
+}
+
+// CommitInProgress returns true if the operation was refused because another
+// commit is running.
+func (e PanosError) CommitInProgress() bool {
+	return e.contains("commit is in progress", "commit in progress")
+}
+
+// ConfigLocked returns true if a config lock is held by another administrator.
+func (e PanosError) ConfigLocked() bool {
+	if e.CommitLocked() {
+		return false
+	}
+	return e.contains("is currently locked", "config is locked", "config locked", "config lock is")
+}
+
+// UnsupportedVersion returns true if PAN-OS rejected the request because the
+// command or config is not known to this PAN-OS version.
+func (e PanosError) UnsupportedVersion() bool {
+	return e.Code == 1 || e.contains("unexpected here")
+}
+
+// contains checks the message and details for any of the given substrings,
+// ignoring case.
+func (e PanosError) contains(vals ...string) bool {
+	msgs := make([]string, 0, len(e.Details)+1)
+	msgs = append(msgs, strings.ToLower(e.Msg))
+	for _, line := range e.Details {
+		msgs = append(msgs, strings.ToLower(line))
+	}
+
+	for _, msg := range msgs {
+		for _, v := range vals {
+			if strings.Contains(msg, v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
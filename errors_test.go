@@ -0,0 +1,68 @@
+package pango
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPanosErrorIs(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		err    PanosError
+		target error
+	}{
+		{"not found", PanosError{Code: 7}, ErrObjectNotFound},
+		{"exists", PanosError{Msg: "foo already exists"}, ErrObjectExists},
+		{"reference", PanosError{Msg: "Reference count not zero", Code: 10}, ErrReferenceInUse},
+		{"xpath", PanosError{Code: 6}, ErrInvalidXpath},
+		{"auth", PanosError{Msg: "Invalid Credential", Code: 403}, ErrAuthFailure},
+		{"session", PanosError{Code: 22}, ErrSessionTimeout},
+		{"commit locked", PanosError{Msg: "Commit lock is already held by admin"}, ErrCommitLocked},
+		{"commit in progress", PanosError{Msg: "Another commit is in progress"}, ErrCommitInProgress},
+		{"config locked", PanosError{Msg: "Config for scope shared is currently locked by admin"}, ErrConfigLocked},
+		{"unsupported", PanosError{Details: []string{"foo", "ip-wildcard unexpected here"}}, ErrUnsupportedVersion},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			wrapped := fmt.Errorf("wrapped: %w", tc.err)
+			if !errors.Is(wrapped, tc.target) {
+				t.Errorf("%#v is not %v", tc.err, tc.target)
+			}
+			if errors.Is(wrapped, errors.New(tc.target.Error())) {
+				t.Errorf("%#v matched an unrelated error", tc.err)
+			}
+		})
+	}
+}
+
+func TestCommitLockIsNotConfigLock(t *testing.T) {
+	err := PanosError{Msg: "Commit is currently locked by admin"}
+	if !errors.Is(err, ErrCommitLocked) || errors.Is(err, ErrConfigLocked) {
+		t.Fail()
+	}
+}
+
+func TestPanosErrorKeepsLineDetails(t *testing.T) {
+	c := &Client{}
+	c.setResponses([][]byte{
+		[]byte(`<response status="error" code="12"><msg><line>Validation Error:</line><line>address -> foo is already in use</line></msg></response>`),
+	})
+	if err := c.Initialize(); err != nil {
+		t.Errorf("Initialize failed: %s", err)
+		return
+	}
+
+	_, err := c.Set("/config/shared/address", "<entry name='foo' />", nil, nil)
+	var pe PanosError
+	if !errors.As(err, &pe) {
+		t.Errorf("Expected a PanosError, got %#v", err)
+	} else if pe.Code != 12 {
+		t.Errorf("Expected code 12, got %d", pe.Code)
+	} else if len(pe.Details) != 2 || pe.Details[1] != "address -> foo is already in use" {
+		t.Errorf("Unexpected details: %#v", pe.Details)
+	} else if pe.Msg != "address -> foo is already in use" {
+		t.Errorf("Unexpected message: %q", pe.Msg)
+	}
+}
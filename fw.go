@@ -1,8 +1,11 @@
 package pango
 
 import (
+    "context"
     "encoding/xml"
 
+    "github.com/inwinstack/pango/util"
+
     // Various namespace imports.
     "github.com/inwinstack/pango/netw"
     "github.com/inwinstack/pango/dev"
@@ -10,6 +13,9 @@ import (
     "github.com/inwinstack/pango/objs"
     "github.com/inwinstack/pango/licen"
     "github.com/inwinstack/pango/userid"
+    "github.com/inwinstack/pango/snapshot"
+    "github.com/inwinstack/pango/jobs"
+    "github.com/inwinstack/pango/updates"
 )
 
 
@@ -24,6 +30,9 @@ import (
 //      * Objects
 //      * Licensing
 //      * UserId
+//      * Snapshots
+//      * Jobs
+//      * Updates
 type Firewall struct {
     Client
 
@@ -34,6 +43,9 @@ type Firewall struct {
     Objects *objs.FwObjs
     Licensing *licen.Licen
     UserId *userid.UserId
+    Snapshots *snapshot.Snapshot
+    Jobs *jobs.Jobs
+    Updates *updates.Updates
 }
 
 // Initialize does some initial setup of the Firewall connection, retrieves
@@ -45,6 +57,8 @@ type Firewall struct {
 //  * Protocol: https
 //  * Port: (unspecified)
 //  * Timeout: 10
+//  * JobPollInterval: 1 second
+//  * JobPollMaxInterval: 10 seconds
 //  * Logging: LogAction | LogUid
 func (c *Firewall) Initialize() error {
     if len(c.rb) == 0 {
@@ -66,6 +80,27 @@ func (c *Firewall) Initialize() error {
     return nil
 }
 
+// WithContext returns a shallow copy of this firewall whose API calls, including
+// those made through any of its namespaces, are bound to the given context.
+func (c *Firewall) WithContext(ctx context.Context) *Firewall {
+    ans := &Firewall{Client: *c.Client.WithContext(ctx)}
+    ans.initNamespaces()
+    ans.copySettings(c)
+
+    return ans
+}
+
+// WithBatch returns a shallow copy of this firewall whose Set, Edit, Delete,
+// and Move calls, including those made through any of its namespaces, are
+// added to the given batch instead of being sent to PAN-OS.
+func (c *Firewall) WithBatch(b *Batch) *Firewall {
+    ans := &Firewall{Client: *c.Client.WithBatch(b)}
+    ans.initNamespaces()
+    ans.copySettings(c)
+
+    return ans
+}
+
 // GetDhcpInfo returns the DHCP client information about the given interface.
 func (c *Firewall) GetDhcpInfo(i string) (map[string] string, error) {
     c.LogOp("(op) show dhcp client state %q", i)
@@ -123,8 +158,24 @@ func (c *Firewall) GetDhcpInfo(i string) (map[string] string, error) {
     }, nil
 }
 
+// DiffVsys returns the uncommitted changes made to the given vsys.
+//
+// If vsys is empty, then "vsys1" is used; specify "shared" for shared config.
+func (c *Firewall) DiffVsys(vsys string) (ConfigDiff, error) {
+    return c.DiffConfig(util.VsysXpathPrefix(vsys))
+}
+
 /** Private functions **/
 
+// copySettings copies the user configurable namespace settings from `s`
+// to this Firewall's namespaces.
+func (c *Firewall) copySettings(s *Firewall) {
+    if s.Updates != nil {
+        c.Updates.RebootPollInterval = s.Updates.RebootPollInterval
+        c.Updates.RebootTimeout = s.Updates.RebootTimeout
+    }
+}
+
 func (c *Firewall) initNamespaces() {
     c.Network = &netw.FwNetw{}
     c.Network.Initialize(c)
@@ -143,4 +194,13 @@ func (c *Firewall) initNamespaces() {
 
     c.UserId = &userid.UserId{}
     c.UserId.Initialize(c)
+
+    c.Snapshots = &snapshot.Snapshot{}
+    c.Snapshots.Initialize(c)
+
+    c.Jobs = &jobs.Jobs{}
+    c.Jobs.Initialize(c)
+
+    c.Updates = &updates.Updates{}
+    c.Updates.Initialize(c)
 }
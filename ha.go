@@ -0,0 +1,374 @@
+package pango
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inwinstack/pango/util"
+)
+
+// HighAvailabilityMonitoring is the state of HA link or path monitoring.
+type HighAvailabilityMonitoring struct {
+	Enabled          bool
+	FailureCondition string
+	Groups           []HighAvailabilityMonitorGroup
+}
+
+// HighAvailabilityMonitorGroup is a single link or path monitoring group.
+type HighAvailabilityMonitorGroup struct {
+	Name             string
+	Enabled          bool
+	FailureCondition string
+	Members          []HighAvailabilityMonitorMember
+}
+
+// HighAvailabilityMonitorMember is a monitored interface, for link
+// monitoring, or a monitored destination IP, for path monitoring.
+type HighAvailabilityMonitorMember struct {
+	Name   string
+	Status string
+}
+
+// haFailoverTimeout is how long HighAvailabilityFailover waits for the other
+// peer to become active if JobTimeout is not set.
+const haFailoverTimeout = 5 * time.Minute
+
+const (
+	haSuspendCmd    = "<request><high-availability><state><suspend /></state></high-availability></request>"
+	haFunctionalCmd = "<request><high-availability><state><functional /></state></high-availability></request>"
+)
+
+// SuspendHighAvailability suspends the local HA peer, making it unable to
+// become active.  If the local peer is currently active, this causes a
+// failover to the other peer.
+func (c *Client) SuspendHighAvailability() error {
+	c.LogOp("(op) suspending high availability")
+	_, err := c.Op(haSuspendCmd, "", nil, nil)
+	return err
+}
+
+// UnsuspendHighAvailability returns a suspended local HA peer to the
+// functional state.
+func (c *Client) UnsuspendHighAvailability() error {
+	c.LogOp("(op) unsuspending high availability")
+	_, err := c.Op(haFunctionalCmd, "", nil, nil)
+	return err
+}
+
+// HighAvailabilityFailover makes the local HA peer give up the active role by
+// suspending it, waiting for the other peer to become active, and then
+// returning it to the functional state.
+//
+// The HA status is polled every JobPollInterval while waiting.  If the other
+// peer has not become active within JobTimeout, or five minutes if JobTimeout
+// is not set, an error is returned.  The local peer is returned to the
+// functional state no matter how the wait ends.
+//
+// Note that if preemption is enabled and the local peer has the higher
+// priority, it will become active again.
+func (c *Client) HighAvailabilityFailover() error {
+	return c.HighAvailabilityFailoverContext(c.Context())
+}
+
+// HighAvailabilityFailoverContext is HighAvailabilityFailover, but waiting
+// for the other peer stops as soon as the given context is cancelled.
+func (c *Client) HighAvailabilityFailoverContext(ctx context.Context) (err error) {
+	// The suspend may have taken effect even if it returned an error, so
+	// always unsuspend.  This is done with a fresh context, as ctx may be
+	// the reason the wait ended.
+	defer func() {
+		c.LogOp("(op) unsuspending high availability")
+		if _, e2 := c.OpContext(context.Background(), haFunctionalCmd, "", nil, nil); err == nil {
+			err = e2
+		}
+	}()
+
+	c.LogOp("(op) suspending high availability")
+	if _, err = c.OpContext(ctx, haSuspendCmd, "", nil, nil); err != nil {
+		return err
+	}
+
+	return c.waitForPeerActive(ctx)
+}
+
+// waitForPeerActive polls the HA status until the other peer is active.
+func (c *Client) waitForPeerActive(ctx context.Context) error {
+	timeout := c.JobTimeout
+	if timeout <= 0 {
+		timeout = haFailoverTimeout
+	}
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c.LogOp("(op) waiting for high availability peer to become active")
+	for {
+		ans := util.HighAvailability{}
+		if _, err := c.OpContext(ctx, "<show><high-availability><all></all></high-availability></show>", "", nil, &ans); err != nil {
+			return c.haWaitError(parent, timeout, err)
+		} else if isActiveState(ans.Group.Peer.State) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return c.haWaitError(parent, timeout, ctx.Err())
+		case <-time.After(c.jobPollInterval()):
+		}
+	}
+}
+
+// haWaitError makes the error returned when the failover times out more
+// descriptive than context.DeadlineExceeded.
+func (c *Client) haWaitError(parent context.Context, timeout time.Duration, err error) error {
+	if err == context.DeadlineExceeded && parent.Err() == nil {
+		return fmt.Errorf("High availability peer did not become active within %s", timeout)
+	}
+	return err
+}
+
+// SyncHighAvailabilityConfig syncs the running config of the local HA peer to
+// the other peer.
+func (c *Client) SyncHighAvailabilityConfig() error {
+	c.LogOp("(op) syncing running config to high availability peer")
+	_, err := c.Op("<request><high-availability><sync-to-remote><running-config /></sync-to-remote></high-availability></request>", "", nil, nil)
+	return err
+}
+
+// GetHighAvailabilityLinkMonitoring returns the state of HA link monitoring.
+func (c *Client) GetHighAvailabilityLinkMonitoring() (*HighAvailabilityMonitoring, error) {
+	c.LogOp("(op) getting high availability link monitoring")
+	ans := haMonitoring{}
+	if _, err := c.Op("<show><high-availability><link-monitoring /></high-availability></show>", "", nil, &ans); err != nil {
+		return nil, err
+	}
+	return ans.normalize(), nil
+}
+
+// GetHighAvailabilityPathMonitoring returns the state of HA path monitoring.
+func (c *Client) GetHighAvailabilityPathMonitoring() (*HighAvailabilityMonitoring, error) {
+	c.LogOp("(op) getting high availability path monitoring")
+	ans := haMonitoring{}
+	if _, err := c.Op("<show><high-availability><path-monitoring /></high-availability></show>", "", nil, &ans); err != nil {
+		return nil, err
+	}
+	return ans.normalize(), nil
+}
+
+// HaPair is a pair of firewalls in an HA cluster.  Config changes should be
+// made through Do(), which sends them to the active firewall.
+//
+//	pair, err := pango.NewHaPair(fw)
+//	...
+//	err = pair.Do(func(active *pango.Firewall) error {
+//	    return active.Objects.Address.Set("vsys1", e)
+//	})
+type HaPair struct {
+	// Local is the firewall that the pair was created from.
+	Local *Firewall
+
+	// Peer is the other firewall, discovered from Local's HA status.
+	Peer *Firewall
+
+	mu     sync.Mutex
+	active *Firewall
+}
+
+// NewHaPair discovers the HA peer of the given initialized firewall, and
+// returns both as a pair.
+//
+// The peer is connected to using its management IP as reported by the HA
+// status, along with a copy of the given firewall's connection settings and
+// credentials.
+func NewHaPair(fw *Firewall) (*HaPair, error) {
+	return newHaPair(fw, func(ip string) *Firewall {
+		return &Firewall{Client: fw.Client.copyTo(ip)}
+	})
+}
+
+// newHaPair is NewHaPair, with the uninitialized peer firewall for the given
+// management IP made by peerFn.
+func newHaPair(fw *Firewall, peerFn func(string) *Firewall) (*HaPair, error) {
+	status, err := fw.GetHighAvailabilityStatus()
+	if err != nil {
+		return nil, err
+	} else if status.Enable != "yes" {
+		return nil, fmt.Errorf("High availability is not enabled on %s", fw.Hostname)
+	}
+
+	ip := status.Group.Peer.ManageIP
+	if idx := strings.Index(ip, "/"); idx != -1 {
+		ip = ip[:idx]
+	}
+	if ip == "" {
+		return nil, fmt.Errorf("No peer management IP reported by %s", fw.Hostname)
+	}
+
+	peer := peerFn(ip)
+	if err = peer.Initialize(); err != nil {
+		return nil, err
+	}
+
+	ans := &HaPair{Local: fw, Peer: peer}
+	ans.active = ans.pick(status.Group.Local.State, status.Group.Peer.State)
+	return ans, nil
+}
+
+// Active returns the active firewall of the pair, checking the HA state of
+// the local firewall to determine which one is active.
+//
+// If the local firewall is unreachable, then the peer's HA state is checked
+// instead.
+func (p *HaPair) Active() (*Firewall, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var active *Firewall
+	if status, err := p.Local.GetHighAvailabilityStatus(); err == nil {
+		active = p.pick(status.Group.Local.State, status.Group.Peer.State)
+	} else if status, e2 := p.Peer.GetHighAvailabilityStatus(); e2 == nil {
+		active = p.pick(status.Group.Peer.State, status.Group.Local.State)
+	} else {
+		return nil, err
+	}
+
+	if active == nil {
+		return nil, fmt.Errorf("Neither firewall in the pair is active")
+	}
+
+	p.active = active
+	return active, nil
+}
+
+// Do calls fn with the active firewall of the pair, as returned by Active().
+//
+// If fn returns an error and the active firewall has changed since fn was
+// called, as happens when the pair fails over, then fn is called once more
+// with the new active firewall.  Otherwise the error from fn is returned.
+func (p *HaPair) Do(fn func(*Firewall) error) error {
+	active, err := p.Active()
+	if err != nil {
+		return err
+	}
+
+	if err = fn(active); err == nil {
+		return nil
+	}
+
+	if now, e2 := p.Active(); e2 == nil && now != active {
+		return fn(now)
+	}
+	return err
+}
+
+// LastActive returns the firewall that was active as of the last check,
+// without checking the HA state again.  This may be nil if no firewall was
+// active during the last check.
+func (p *HaPair) LastActive() *Firewall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active
+}
+
+// pick returns the active firewall given the states of the local and peer
+// firewalls.
+func (p *HaPair) pick(local, peer string) *Firewall {
+	switch {
+	case isActiveState(local):
+		return p.Local
+	case isActiveState(peer):
+		return p.Peer
+	}
+	return nil
+}
+
+// isActiveState returns true for the states that accept config changes:
+// active in active-passive mode, and active-primary in active-active mode.
+func isActiveState(s string) bool {
+	return s == "active" || s == "active-primary"
+}
+
+// copyTo returns a copy of this client's connection settings, credentials,
+// and logging configuration, for connecting to the given hostname.
+func (c *Client) copyTo(hostname string) Client {
+	return Client{
+		Hostname:              hostname,
+		Username:              c.Username,
+		Password:              c.Password,
+		ApiKey:                c.ApiKey,
+		Protocol:              c.Protocol,
+		Port:                  c.Port,
+		Timeout:               c.Timeout,
+		Target:                c.Target,
+		Logging:               c.Logging,
+		Logger:                c.Logger,
+		JobPollInterval:       c.JobPollInterval,
+		JobPollMaxInterval:    c.JobPollMaxInterval,
+		JobTimeout:            c.JobTimeout,
+		JobProgress:           c.JobProgress,
+		Retry:                 c.Retry,
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+		RequestsPerSecond:     c.RequestsPerSecond,
+		RequestBurst:          c.RequestBurst,
+		VerifyCertificate:     c.VerifyCertificate,
+		CaFile:                c.CaFile,
+		ClientCertFile:        c.ClientCertFile,
+		ClientKeyFile:         c.ClientKeyFile,
+		ProxyUrl:              c.ProxyUrl,
+		WrapTransport:         c.WrapTransport,
+		ctx:                   c.ctx,
+	}
+}
+
+/** Internal structs **/
+
+type haMonitoring struct {
+	XMLName          xml.Name         `xml:"response"`
+	Enabled          string           `xml:"result>enabled"`
+	FailureCondition string           `xml:"result>failure-condition"`
+	Groups           []haMonitorGroup `xml:"result>groups>entry"`
+}
+
+type haMonitorGroup struct {
+	Name             string            `xml:"name"`
+	Enabled          string            `xml:"enabled"`
+	FailureCondition string            `xml:"failure-condition"`
+	Interfaces       []haMonitorMember `xml:"interface>entry"`
+	Destinations     []haMonitorMember `xml:"destination-groups>entry>dest-ip>entry"`
+}
+
+type haMonitorMember struct {
+	Name   string `xml:"name"`
+	Ip     string `xml:"ip"`
+	Status string `xml:"status"`
+}
+
+func (o haMonitoring) normalize() *HighAvailabilityMonitoring {
+	ans := &HighAvailabilityMonitoring{
+		Enabled:          util.AsBool(o.Enabled),
+		FailureCondition: o.FailureCondition,
+		Groups:           make([]HighAvailabilityMonitorGroup, 0, len(o.Groups)),
+	}
+
+	for _, g := range o.Groups {
+		grp := HighAvailabilityMonitorGroup{
+			Name:             g.Name,
+			Enabled:          util.AsBool(g.Enabled),
+			FailureCondition: g.FailureCondition,
+		}
+		for _, m := range append(g.Interfaces, g.Destinations...) {
+			name := m.Name
+			if name == "" {
+				name = m.Ip
+			}
+			grp.Members = append(grp.Members, HighAvailabilityMonitorMember{Name: name, Status: m.Status})
+		}
+		ans.Groups = append(ans.Groups, grp)
+	}
+
+	return ans
+}
@@ -0,0 +1,249 @@
+package pango
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func haStatusXml(local, peer string) []byte {
+	return []byte(fmt.Sprintf(`<response status="success"><result><enabled>yes</enabled><group>
+        <mode>Active-Passive</mode>
+        <local-info><state>%s</state><mgmt-ip>10.0.0.1/24</mgmt-ip></local-info>
+        <peer-info><state>%s</state><mgmt-ip>10.0.0.2/24</mgmt-ip></peer-info>
+    </group></result></response>`, local, peer))
+}
+
+func TestHighAvailabilityOps(t *testing.T) {
+	testCases := []struct {
+		desc string
+		fn   func(*Client) error
+		cmds []string
+	}{
+		{"suspend", (*Client).SuspendHighAvailability, []string{
+			"<request><high-availability><state><suspend /></state></high-availability></request>",
+		}},
+		{"sync", (*Client).SyncHighAvailabilityConfig, []string{
+			"<request><high-availability><sync-to-remote><running-config /></sync-to-remote></high-availability></request>",
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &Client{Logging: LogQuiet}
+			resps := make([][]byte, len(tc.cmds))
+			for i := range resps {
+				resps[i] = []byte(`<response status="success"><result/></response>`)
+			}
+			c.setResponses(resps)
+
+			if err := tc.fn(c); err != nil {
+				t.Fatalf("Error: %s", err)
+			}
+			if len(c.rp) != len(tc.cmds) {
+				t.Fatalf("Expected %d calls, got %d", len(tc.cmds), len(c.rp))
+			}
+			for i := range tc.cmds {
+				if c.rp[i].Get("cmd") != tc.cmds[i] {
+					t.Errorf("Expected %s, got %s", tc.cmds[i], c.rp[i].Get("cmd"))
+				}
+			}
+		})
+	}
+}
+
+func TestHighAvailabilityFailover(t *testing.T) {
+	ok := []byte(`<response status="success"><result/></response>`)
+	c := &Client{Logging: LogQuiet, JobPollInterval: time.Millisecond}
+	c.setResponses([][]byte{
+		ok,
+		haStatusXml("suspended", "passive"),
+		haStatusXml("suspended", "active"),
+		ok,
+	})
+
+	if err := c.HighAvailabilityFailover(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+
+	cmds := []string{
+		"<request><high-availability><state><suspend /></state></high-availability></request>",
+		"<show><high-availability><all></all></high-availability></show>",
+		"<show><high-availability><all></all></high-availability></show>",
+		"<request><high-availability><state><functional /></state></high-availability></request>",
+	}
+	if len(c.rp) != len(cmds) {
+		t.Fatalf("Expected %d calls, got %d", len(cmds), len(c.rp))
+	}
+	for i := range cmds {
+		if c.rp[i].Get("cmd") != cmds[i] {
+			t.Errorf("Expected %s, got %s", cmds[i], c.rp[i].Get("cmd"))
+		}
+	}
+}
+
+func TestHighAvailabilityFailoverCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Client{Logging: LogQuiet, JobPollInterval: time.Hour}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success"><result/></response>`),
+		haStatusXml("suspended", "passive"),
+		[]byte(`<response status="success"><result/></response>`),
+	})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if err := c.HighAvailabilityFailoverContext(ctx); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if cmd := c.rp[len(c.rp)-1].Get("cmd"); cmd != "<request><high-availability><state><functional /></state></high-availability></request>" {
+		t.Errorf("Local peer was left suspended, last command: %s", cmd)
+	}
+}
+
+func TestHighAvailabilityFailoverTimeout(t *testing.T) {
+	c := &Client{Logging: LogQuiet, JobPollInterval: 10 * time.Millisecond, JobTimeout: 30 * time.Millisecond}
+	resps := [][]byte{[]byte(`<response status="success"><result/></response>`)}
+	for i := 0; i < 100; i++ {
+		resps = append(resps, haStatusXml("suspended", "passive"))
+	}
+	c.setResponses(resps)
+
+	err := c.HighAvailabilityFailover()
+	if err == nil || !strings.Contains(err.Error(), "did not become active") {
+		t.Fatalf("Expected a timeout error, got %v", err)
+	}
+	if cmd := c.rp[len(c.rp)-1].Get("cmd"); cmd != haFunctionalCmd {
+		t.Errorf("Local peer was left suspended, last command: %s", cmd)
+	}
+}
+
+func TestHighAvailabilityLinkMonitoring(t *testing.T) {
+	c := &Client{Logging: LogQuiet}
+	c.setResponses([][]byte{[]byte(`<response status="success"><result>
+        <enabled>yes</enabled><failure-condition>any</failure-condition>
+        <groups><entry>
+            <name>uplinks</name><enabled>yes</enabled><failure-condition>all</failure-condition>
+            <interface><entry><name>ethernet1/1</name><status>up</status></entry><entry><name>ethernet1/2</name><status>down</status></entry></interface>
+        </entry></groups>
+    </result></response>`)})
+
+	ans, err := c.GetHighAvailabilityLinkMonitoring()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if !ans.Enabled || ans.FailureCondition != "any" || len(ans.Groups) != 1 {
+		t.Fatalf("Unexpected monitoring: %#v", ans)
+	}
+	g := ans.Groups[0]
+	if g.Name != "uplinks" || !g.Enabled || g.FailureCondition != "all" || len(g.Members) != 2 {
+		t.Errorf("Unexpected group: %#v", g)
+	} else if g.Members[1].Name != "ethernet1/2" || g.Members[1].Status != "down" {
+		t.Errorf("Unexpected member: %#v", g.Members[1])
+	}
+}
+
+func TestHaPair(t *testing.T) {
+	fw := &Firewall{Client: Client{Username: "admin", Password: "secret", Logging: LogQuiet}}
+	fw.setResponses([][]byte{haStatusXml("passive", "active")})
+	fw.Initialize()
+
+	var peer *Firewall
+	pair, err := newHaPair(fw, func(ip string) *Firewall {
+		if ip != "10.0.0.2" {
+			t.Errorf("Expected peer IP 10.0.0.2, got %q", ip)
+		}
+		peer = &Firewall{Client: fw.Client.copyTo(ip)}
+		peer.setResponses([][]byte{haStatusXml("active", "passive")})
+		return peer
+	})
+	if err != nil {
+		t.Fatalf("Error in newHaPair: %s", err)
+	}
+	if pair.Peer != peer || pair.Local != fw {
+		t.Fatalf("Pair is not the local and peer firewalls")
+	} else if peer.Username != "admin" || peer.Objects == nil {
+		t.Errorf("Peer not initialized: %#v", peer.Client)
+	}
+	if pair.LastActive() != pair.Peer {
+		t.Errorf("Peer was not discovered as active")
+	}
+
+	fw.setResponses([][]byte{haStatusXml("active", "passive")})
+	if active, err := pair.Active(); err != nil {
+		t.Errorf("Error in Active: %s", err)
+	} else if active != pair.Local {
+		t.Errorf("Local was not discovered as active")
+	}
+
+	fw.setResponses([][]byte{haStatusXml("suspended", "non-functional")})
+	if _, err := pair.Active(); err == nil {
+		t.Errorf("Expected an error when neither is active")
+	}
+}
+
+func TestHaPairDo(t *testing.T) {
+	local := &Firewall{Client: Client{Logging: LogQuiet}}
+	local.setResponses([][]byte{haStatusXml("active", "passive")})
+	local.Initialize()
+	peer := &Firewall{Client: Client{Logging: LogQuiet}}
+	peer.setResponses([][]byte{haStatusXml("passive", "active")})
+	peer.Initialize()
+	pair := &HaPair{Local: local, Peer: peer}
+
+	var got []*Firewall
+	err := pair.Do(func(fw *Firewall) error {
+		got = append(got, fw)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error in Do: %s", err)
+	} else if len(got) != 1 || got[0] != local {
+		t.Fatalf("Expected one call on the local firewall, got %v", got)
+	}
+
+	// The local firewall fails over during the call.
+	got = nil
+	err = pair.Do(func(fw *Firewall) error {
+		got = append(got, fw)
+		if fw == local {
+			local.setResponses([][]byte{haStatusXml("suspended", "active")})
+			local.ri = 0
+			return fmt.Errorf("connection reset")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error in Do after failover: %s", err)
+	} else if len(got) != 2 || got[0] != local || got[1] != peer {
+		t.Fatalf("Expected a retry on the peer, got %v", got)
+	}
+	if pair.LastActive() != peer {
+		t.Errorf("Peer was not discovered as active")
+	}
+
+	// Errors are returned as-is when there is no failover.
+	got = nil
+	err = pair.Do(func(fw *Firewall) error {
+		got = append(got, fw)
+		return fmt.Errorf("bad config")
+	})
+	if err == nil || err.Error() != "bad config" || len(got) != 1 {
+		t.Errorf("Expected one failed call, got %v: %v", got, err)
+	}
+}
+
+func TestHaPairNotEnabled(t *testing.T) {
+	fw := &Firewall{Client: Client{Logging: LogQuiet}}
+	fw.setResponses([][]byte{[]byte(`<response status="success"><result><enabled>no</enabled></result></response>`)})
+	fw.Initialize()
+
+	if _, err := NewHaPair(fw); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
@@ -0,0 +1,136 @@
+// Package jobs is the client.Jobs namespace.
+//
+// It lists, inspects, and stops the jobs on a PAN-OS device, such as commits,
+// downloads, and installs.
+package jobs
+
+import (
+    "encoding/xml"
+    "fmt"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Jobs is the client.Jobs namespace.
+type Jobs struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked on client.Initialize().
+func (c *Jobs) Initialize(i util.XapiClient) {
+    c.con = i
+}
+
+// All returns all jobs.
+func (c *Jobs) All() ([]util.Job, error) {
+    type req struct {
+        XMLName xml.Name `xml:"show"`
+        Cmd string `xml:"jobs>all"`
+    }
+
+    c.con.LogOp("(op) show jobs all")
+    return c.list(req{})
+}
+
+// Pending returns all jobs that have not yet started.
+func (c *Jobs) Pending() ([]util.Job, error) {
+    type req struct {
+        XMLName xml.Name `xml:"show"`
+        Cmd string `xml:"jobs>pending"`
+    }
+
+    c.con.LogOp("(op) show jobs pending")
+    return c.list(req{})
+}
+
+// Active returns all jobs of the given type that have not finished, such
+// as commits started by other administrators or automation.
+//
+// If jobType is an empty string, then active jobs of all types are returned.
+func (c *Jobs) Active(jobType string) ([]util.Job, error) {
+    list, err := c.All()
+    if err != nil {
+        return nil, err
+    }
+
+    ans := make([]util.Job, 0, len(list))
+    for _, j := range list {
+        if !j.Done() && (jobType == "" || j.Type == jobType) {
+            ans = append(ans, j)
+        }
+    }
+
+    return ans, nil
+}
+
+// Get returns the details of the given job.
+func (c *Jobs) Get(id uint) (util.Job, error) {
+    type req struct {
+        XMLName xml.Name `xml:"show"`
+        Id uint `xml:"jobs>id"`
+    }
+
+    c.con.LogOp("(op) show jobs id %d", id)
+    list, err := c.list(req{Id: id})
+    if err != nil {
+        return util.Job{}, err
+    } else if len(list) != 1 {
+        return util.Job{}, fmt.Errorf("Expected 1 job, got %d", len(list))
+    }
+
+    return list[0], nil
+}
+
+// Stop stops the given job.
+func (c *Jobs) Stop(id uint) error {
+    type req struct {
+        XMLName xml.Name `xml:"clear"`
+        Id uint `xml:"job>id"`
+    }
+
+    c.con.LogOp("(op) clear job id %d", id)
+    _, err := c.con.Op(req{Id: id}, "", nil, nil)
+    return err
+}
+
+// WaitForActive waits for all active jobs of the given type to finish,
+// such as waiting for commits started by other automation before starting
+// a new commit.
+//
+// If jobType is an empty string, then active jobs of all types are waited on.
+//
+// The first error encountered is returned, which includes any of the active
+// jobs having failed.
+func (c *Jobs) WaitForActive(jobType string) error {
+    list, err := c.Active(jobType)
+    if err != nil {
+        return err
+    }
+
+    for _, j := range list {
+        if e2 := c.con.WaitForJob(j.Id, nil); e2 != nil && err == nil {
+            err = e2
+        }
+    }
+
+    return err
+}
+
+/** Internal functions **/
+
+func (c *Jobs) list(req interface{}) ([]util.Job, error) {
+    ans := jobList{}
+    if _, err := c.con.Op(req, "", nil, &ans); err != nil {
+        return nil, err
+    }
+
+    return ans.Jobs, nil
+}
+
+/** Internal structs **/
+
+type jobList struct {
+    XMLName xml.Name `xml:"response"`
+    Jobs []util.Job `xml:"result>job"`
+}
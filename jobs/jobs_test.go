@@ -0,0 +1,100 @@
+package jobs
+
+import (
+    "testing"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+const jobsXml = `<job>
+    <id>3</id><type>Commit</type><user>bot</user><status>ACT</status><result>PEND</result><progress>40</progress>
+    <tenq>2020/01/02 03:04:05</tenq><tdeq>03:04:06</tdeq>
+</job>
+<job>
+    <id>2</id><type>Downld</type><user>admin</user><status>FIN</status><result>OK</result><progress>2020/01/02 03:00:00</progress>
+    <tfin>2020/01/02 03:00:00</tfin><warnings><line>careful</line></warnings>
+</job>
+<job>
+    <id>1</id><type>Commit</type><user>admin</user><status>FIN</status><result>FAIL</result><progress>100</progress>
+    <details><line>commit failed</line></details>
+</job>`
+
+
+func TestAll(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp(jobsXml)
+    c := &Jobs{}
+    c.Initialize(mc)
+
+    list, err := c.All()
+    if err != nil {
+        t.Fatalf("Error in all: %s", err)
+    }
+    if mc.Elm != "<show><jobs><all></all></jobs></show>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+    if len(list) != 3 {
+        t.Fatalf("Expected 3 jobs, got %d", len(list))
+    }
+    if j := list[0]; j.Id != 3 || j.Type != "Commit" || j.User != "bot" || j.Progress != 40 || j.Enqueued != "2020/01/02 03:04:05" || j.Done() {
+        t.Errorf("Unexpected job: %#v", j)
+    }
+    if j := list[1]; j.Progress != 100 || !j.Done() || len(j.Warnings) != 1 {
+        t.Errorf("Unexpected job: %#v", j)
+    }
+    if j := list[2]; j.Result != "FAIL" || len(j.Details) != 1 || j.Details[0] != "commit failed" {
+        t.Errorf("Unexpected job: %#v", j)
+    }
+}
+
+func TestActive(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp(jobsXml)
+    c := &Jobs{}
+    c.Initialize(mc)
+
+    testCases := []struct{
+        desc string
+        jobType string
+        count int
+    }{
+        {"all types", "", 1},
+        {"commits", "Commit", 1},
+        {"downloads", "Downld", 0},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            list, err := c.Active(tc.jobType)
+            if err != nil {
+                t.Fatalf("Error in active: %s", err)
+            } else if len(list) != tc.count {
+                t.Errorf("Expected %d jobs, got %d", tc.count, len(list))
+            }
+        })
+    }
+}
+
+func TestGetAndStop(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp("<job><id>7</id><type>Commit</type><status>FIN</status><result>OK</result><progress>100</progress></job>")
+    c := &Jobs{}
+    c.Initialize(mc)
+
+    j, err := c.Get(7)
+    if err != nil {
+        t.Fatalf("Error in get: %s", err)
+    } else if j.Id != 7 {
+        t.Errorf("Unexpected job: %#v", j)
+    }
+    if mc.Elm != "<show><jobs><id>7</id></jobs></show>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+
+    if err = c.Stop(7); err != nil {
+        t.Errorf("Error in stop: %s", err)
+    } else if mc.Elm != "<clear><job><id>7</id></job></clear>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+}
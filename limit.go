@@ -0,0 +1,126 @@
+package pango
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter bounds the number of concurrent API calls with a semaphore, and
+// the rate of API calls with a token bucket.  A single limiter is shared by
+// a Client and all copies of it made by WithContext().
+type limiter struct {
+	sem chan struct{}
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter returns a limiter, or nil if no limits are configured.
+func newLimiter(concurrency int, rate float64, burst int) *limiter {
+	if concurrency <= 0 && rate <= 0 {
+		return nil
+	}
+
+	ans := &limiter{}
+	if concurrency > 0 {
+		ans.sem = make(chan struct{}, concurrency)
+	}
+	if rate > 0 {
+		ans.rate = rate
+		ans.burst = float64(burst)
+		if ans.burst < 1 {
+			ans.burst = 1
+		}
+		ans.tokens = ans.burst
+		ans.last = time.Now()
+	}
+
+	return ans
+}
+
+// acquire blocks until an API call is allowed to be made or the context is
+// done.  Each successful acquire must be followed by a release.
+func (l *limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// Only take a token if the call will actually be made.
+	if err := ctx.Err(); err != nil {
+		l.release()
+		return err
+	}
+
+	if wait := l.reserve(); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			l.refund()
+			l.release()
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// release frees the concurrency slot taken by acquire.
+func (l *limiter) release() {
+	if l != nil && l.sem != nil {
+		<-l.sem
+	}
+}
+
+// reserve takes a token from the bucket, returning how long the caller must
+// wait before the token is actually available.
+func (l *limiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// refund returns a token taken by reserve that went unused.
+func (l *limiter) refund() {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
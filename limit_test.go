@@ -0,0 +1,116 @@
+package pango
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var cur, max int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&cur, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&cur, -1)
+		w.Write([]byte(`<response status="success"><result /></response>`))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	c := &Client{
+		Hostname:              u.Host,
+		Protocol:              "http",
+		Logging:               LogQuiet,
+		MaxConcurrentRequests: 2,
+	}
+	if err := c.initCon(); err != nil {
+		t.Fatalf("initCon failed: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Op("<show><system><info /></system></show>", "", nil, nil); err != nil {
+				t.Errorf("Error in op: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if max > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", max)
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	l := newLimiter(0, 100, 1)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("Error in acquire: %s", err)
+		}
+		l.release()
+	}
+
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Errorf("5 requests at 100/s with a burst of 1 took only %s", d)
+	}
+}
+
+func TestLimiterHonorsContext(t *testing.T) {
+	l := newLimiter(1, 0, 0)
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("Error in acquire: %s", err)
+	}
+	defer l.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestLimiterRefundsCanceledTokens(t *testing.T) {
+	l := newLimiter(0, 1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.acquire(ctx); err != context.Canceled {
+		t.Fatalf("Expected canceled, got %v", err)
+	} else if l.tokens != 1 {
+		t.Errorf("Canceled acquire took a token, %v left", l.tokens)
+	}
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("Error in acquire: %s", err)
+	}
+	l.release()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	} else if l.tokens < -0.5 {
+		t.Errorf("Token for the timed out acquire was not refunded, %v left", l.tokens)
+	}
+}
+
+func TestNoLimiter(t *testing.T) {
+	if newLimiter(0, 0, 0) != nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,170 @@
+package pango
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/inwinstack/pango/util"
+)
+
+// LockGuard holds both the config lock and the commit lock for a single
+// scope.  It is returned from AcquireLocks(), and its Release() should always
+// be called, typically with defer:
+//
+//      g, err := fw.AcquireLocks("vsys1", "terraform")
+//      if err != nil {
+//          return err
+//      }
+//      defer g.Release()
+type LockGuard struct {
+	con     *Client
+	vsys    string
+	comment string
+
+	mu       sync.Mutex
+	released bool
+}
+
+// AcquireLocks takes both the config lock and the commit lock for the given
+// scope, using the given comment.
+//
+// Before locking, any locks for the scope that are owned by this client's
+// Username and that have the same comment are considered to be stale locks
+// left behind by a previous run that did not release them, and are removed.
+// Because of this, the comment should identify the service taking the locks,
+// and the same Username and comment should not be used concurrently.
+//
+// If the commit lock cannot be taken, the config lock is released before
+// the error is returned.
+//
+// If vsys is an empty string, the scope defaults to "shared".
+func (c *Client) AcquireLocks(vsys, comment string) (*LockGuard, error) {
+	if vsys == "" {
+		vsys = "shared"
+	}
+
+	g := &LockGuard{con: c, vsys: vsys, comment: comment}
+
+	cfgLock, cmtLock, err := g.owned()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfgLock != nil && cfgLock.Comment.Text == comment {
+		c.LogAction("(lock) removing stale config lock for scope %q", vsys)
+		if err = c.UnlockConfig(vsys); err != nil {
+			return nil, err
+		}
+	} else if cfgLock != nil {
+		return nil, fmt.Errorf("Config lock for %q is already held by %q: %s", vsys, cfgLock.Owner, cfgLock.Comment.Text)
+	}
+
+	if cmtLock != nil && cmtLock.Comment.Text == comment {
+		c.LogAction("(lock) removing stale commit lock for scope %q", vsys)
+		if err = c.UnlockCommits(vsys, ""); err != nil {
+			return nil, err
+		}
+	} else if cmtLock != nil {
+		return nil, fmt.Errorf("Commit lock for %q is already held by %q: %s", vsys, cmtLock.Owner, cmtLock.Comment.Text)
+	}
+
+	if err = c.LockConfig(vsys, comment); err != nil {
+		return nil, err
+	}
+	if err = c.LockCommits(vsys, comment); err != nil {
+		c.UnlockConfig(vsys)
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Vsys returns the scope that is locked.
+func (g *LockGuard) Vsys() string {
+	return g.vsys
+}
+
+// Verify checks that both locks are still held, re-acquiring any lock that
+// was removed by another administrator since it was taken.
+//
+// Locks are identified by owner, so the client's Username must be set.
+func (g *LockGuard) Verify() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.released {
+		return fmt.Errorf("Locks for %q have already been released", g.vsys)
+	} else if g.con.Username == "" {
+		return fmt.Errorf("Username is required to verify locks")
+	}
+
+	cfgLock, cmtLock, err := g.owned()
+	if err != nil {
+		return err
+	}
+
+	if cfgLock == nil {
+		g.con.LogAction("(lock) config lock for scope %q was removed, re-acquiring", g.vsys)
+		if err = g.con.LockConfig(g.vsys, g.comment); err != nil {
+			return err
+		}
+	}
+	if cmtLock == nil {
+		g.con.LogAction("(lock) commit lock for scope %q was removed, re-acquiring", g.vsys)
+		if err = g.con.LockCommits(g.vsys, g.comment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Release removes both the commit lock and the config lock.  It is safe to
+// call Release more than once; only the first call has any effect.
+//
+// Both locks are always attempted to be removed; the first error encountered
+// is returned.
+func (g *LockGuard) Release() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.released {
+		return nil
+	}
+	g.released = true
+
+	err := g.con.UnlockCommits(g.vsys, "")
+	if e2 := g.con.UnlockConfig(g.vsys); err == nil {
+		err = e2
+	}
+	return err
+}
+
+// owned returns the config and commit locks for this scope that are held by
+// this client's Username, if any.  Locks held by other administrators are not
+// returned.
+func (g *LockGuard) owned() (*util.Lock, *util.Lock, error) {
+	cfgLocks, err := g.con.ConfigLocks(g.vsys)
+	if err != nil {
+		return nil, nil, err
+	}
+	cmtLocks, err := g.con.CommitLocks(g.vsys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return g.find(cfgLocks), g.find(cmtLocks), nil
+}
+
+func (g *LockGuard) find(list []util.Lock) *util.Lock {
+	if g.con.Username == "" {
+		return nil
+	}
+
+	for i := range list {
+		if list[i].Owner == g.con.Username {
+			return &list[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package pango
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	noLocksResp     = `<response status="success"><result><config-locks/><commit-locks/></result></response>`
+	successResp     = `<response status="success"><result>Successfully acquired lock</result></response>`
+	staleCfgResp    = `<response status="success"><result><config-locks><entry name="svc"><name>vsys1</name><comment><![CDATA[deployer]]></comment></entry></config-locks></result></response>`
+	staleCommitResp = `<response status="success"><result><commit-locks><entry name="svc"><name>vsys1</name><comment><![CDATA[deployer]]></comment></entry></commit-locks></result></response>`
+	otherCfgResp    = `<response status="success"><result><config-locks><entry name="bob"><name>vsys1</name><comment><![CDATA[mine]]></comment></entry></config-locks></result></response>`
+)
+
+func lockClient(resps ...string) *Client {
+	c := &Client{Username: "svc", Logging: LogQuiet}
+	rb := make([][]byte, 0, len(resps))
+	for _, r := range resps {
+		rb = append(rb, []byte(r))
+	}
+	c.setResponses(rb)
+	return c
+}
+
+func cmds(c *Client) []string {
+	ans := make([]string, 0, len(c.rp))
+	for _, v := range c.rp {
+		ans = append(ans, v.Get("cmd"))
+	}
+	return ans
+}
+
+func TestAcquireLocks(t *testing.T) {
+	c := lockClient(noLocksResp, noLocksResp, successResp, successResp, successResp, successResp)
+
+	g, err := c.AcquireLocks("vsys1", "deployer")
+	if err != nil {
+		t.Fatalf("Error acquiring locks: %s", err)
+	}
+	if err = g.Release(); err != nil {
+		t.Errorf("Error releasing locks: %s", err)
+	}
+	if err = g.Release(); err != nil {
+		t.Errorf("Error releasing locks twice: %s", err)
+	}
+
+	sent := cmds(c)
+	expected := []string{
+		"<show><config-locks /></show>",
+		"<show><commit-locks /></show>",
+		"<request><config-lock><add><comment>deployer</comment></add></config-lock></request>",
+		"<request><commit-lock><add><comment>deployer</comment></add></commit-lock></request>",
+		"<request><commit-lock><remove></remove></commit-lock></request>",
+		"<request><config-lock><remove></remove></config-lock></request>",
+	}
+	if len(sent) != len(expected) {
+		t.Fatalf("Expected %d calls, got %d: %v", len(expected), len(sent), sent)
+	}
+	for i := range expected {
+		if sent[i] != expected[i] {
+			t.Errorf("Call %d: expected %q, got %q", i, expected[i], sent[i])
+		}
+	}
+	if c.rp[2].Get("vsys") != "vsys1" {
+		t.Errorf("Lock not scoped to vsys1: %q", c.rp[2].Get("vsys"))
+	}
+}
+
+func TestAcquireLocksRemovesStale(t *testing.T) {
+	c := lockClient(staleCfgResp, staleCommitResp, successResp, successResp, successResp, successResp)
+
+	if _, err := c.AcquireLocks("vsys1", "deployer"); err != nil {
+		t.Fatalf("Error acquiring locks: %s", err)
+	}
+
+	sent := cmds(c)
+	if len(sent) != 6 {
+		t.Fatalf("Expected 6 calls, got %d: %v", len(sent), sent)
+	} else if !strings.Contains(sent[2], "<config-lock><remove>") || !strings.Contains(sent[3], "<commit-lock><remove>") {
+		t.Errorf("Stale locks not removed: %v", sent)
+	}
+}
+
+func TestAcquireLocksHeldByOther(t *testing.T) {
+	c := lockClient(otherCfgResp, noLocksResp, `<response status="error"><msg><line>Config for scope vsys1 is currently locked by bob</line></msg></response>`)
+
+	if _, err := c.AcquireLocks("vsys1", "deployer"); err == nil {
+		t.Fatalf("Expected an error")
+	} else if e, ok := err.(PanosError); !ok || !e.ConfigLocked() {
+		t.Errorf("Expected a config locked error, got %#v", err)
+	}
+
+	for _, s := range cmds(c) {
+		if strings.Contains(s, "remove") || strings.Contains(s, "commit-lock><add") {
+			t.Errorf("Unexpected call: %s", s)
+		}
+	}
+}
+
+func TestLockGuardVerify(t *testing.T) {
+	c := lockClient(noLocksResp, noLocksResp, successResp, successResp, staleCfgResp, noLocksResp, successResp)
+
+	g, err := c.AcquireLocks("vsys1", "deployer")
+	if err != nil {
+		t.Fatalf("Error acquiring locks: %s", err)
+	}
+	if err = g.Verify(); err != nil {
+		t.Errorf("Error verifying locks: %s", err)
+	}
+
+	sent := cmds(c)
+	if len(sent) != 7 {
+		t.Fatalf("Expected 7 calls, got %d: %v", len(sent), sent)
+	} else if sent[6] != "<request><commit-lock><add><comment>deployer</comment></add></commit-lock></request>" {
+		t.Errorf("Commit lock not re-acquired: %s", sent[6])
+	}
+}
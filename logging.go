@@ -0,0 +1,186 @@
+package pango
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/inwinstack/pango/util"
+)
+
+// Logger is a leveled, structured logger.  The args are alternating key /
+// value pairs, so a *slog.Logger from the log/slog package satisfies this
+// interface.
+//
+// If a Client is given a Logger, all log messages are sent to it instead of
+// the standard library's log package, and every API call is additionally
+// logged at the debug level with the following keys:
+//
+//      * operation: the API call type and action (e.g. - "config/set")
+//      * xpath: the xpath, if any
+//      * vsys: the vsys, if any
+//      * device_group: the device group in the xpath, if any
+//      * template: the template or template stack in the xpath, if any
+//      * duration: how long the API call took
+//      * code: the PAN-OS response code, if any
+//      * job: the job ID, if a job was submitted
+//      * error: the error encountered, if any (logged at the warn level)
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Placeholder text for redacted secrets.
+const redacted = "########"
+
+// Url params that carry secrets.
+var secretParams = []string{"key", "password", "passphrase"}
+
+// reSecretXml matches XML nodes whose contents are secret: passwords,
+// password hashes, API keys, pre-shared keys, SNMP communities, etc.
+var reSecretXml = regexp.MustCompile(`<((?:[\w-]+-)?(?:password|passwd|passphrase|secret|phash|key|psk|community))(\s[^>]*)?>(<!\[CDATA\[[\s\S]*?\]\]>|[^<]*)</([\w-]+)>`)
+
+// RedactXml replaces the contents of any XML node in the given document
+// that typically carries a secret with a placeholder.
+func RedactXml(v string) string {
+	return reSecretXml.ReplaceAllStringFunc(v, func(m string) string {
+		sm := reSecretXml.FindStringSubmatch(m)
+		if sm[1] != sm[4] || sm[3] == "" {
+			return m
+		}
+		return fmt.Sprintf("<%s%s>%s</%s>", sm[1], sm[2], redacted, sm[4])
+	})
+}
+
+// redactValues returns a copy of the given url params with secrets redacted.
+func redactValues(data url.Values) url.Values {
+	ans := make(url.Values, len(data))
+	for k := range data {
+		ans[k] = make([]string, len(data[k]))
+		for i := range data[k] {
+			ans[k][i] = RedactXml(data[k][i])
+		}
+	}
+
+	for _, k := range secretParams {
+		if ans.Get(k) != "" {
+			ans.Set(k, redacted)
+		}
+	}
+
+	return ans
+}
+
+// logf sends a formatted log message to the client's Logger at the info
+// level if one is configured, otherwise to the standard library logger.
+func (c *Client) logf(category, msg string, i ...interface{}) {
+	if c.Logger == nil {
+		log.Printf(msg, i...)
+	} else {
+		c.Logger.Info(fmt.Sprintf(msg, i...), "category", category)
+	}
+}
+
+// logSend logs the data being sent to PAN-OS if LogSend is set.
+func (c *Client) logSend(data url.Values) {
+	if c.Logging&LogSend != LogSend || c.Logging&LogQuiet == LogQuiet {
+		return
+	}
+
+	safe := redactValues(data)
+	if c.Logger == nil {
+		log.Printf("Sending data: %#v", safe)
+	} else {
+		c.Logger.Debug("sending data", "data", safe.Encode())
+	}
+}
+
+// logReceive logs the response from PAN-OS if LogReceive is set.
+func (c *Client) logReceive(body []byte) {
+	if c.Logging&LogReceive != LogReceive || c.Logging&LogQuiet == LogQuiet {
+		return
+	}
+
+	safe := RedactXml(string(body))
+	if c.Logger == nil {
+		log.Printf("Response = %s", safe)
+	} else {
+		c.Logger.Debug("received response", "response", safe)
+	}
+}
+
+// logCall logs a structured summary of a single API call to the Logger.
+func (c *Client) logCall(data url.Values, start time.Time, body []byte, err error) {
+	if c.Logger == nil || c.Logging&LogQuiet == LogQuiet {
+		return
+	}
+
+	op := data.Get("type")
+	if action := data.Get("action"); action != "" {
+		op = fmt.Sprintf("%s/%s", op, action)
+	}
+
+	args := make([]interface{}, 0, 18)
+	args = append(args, "operation", op)
+	if xp := data.Get("xpath"); xp != "" {
+		args = append(args, "xpath", xp)
+		if dg := xpathEntryName(xp, "device-group"); dg != "" {
+			args = append(args, "device_group", dg)
+		}
+		if tmpl := xpathEntryName(xp, "template"); tmpl != "" {
+			args = append(args, "template", tmpl)
+		} else if ts := xpathEntryName(xp, "template-stack"); ts != "" {
+			args = append(args, "template", ts)
+		}
+	}
+	if vsys := data.Get("vsys"); vsys != "" {
+		args = append(args, "vsys", vsys)
+	}
+	args = append(args, "duration", time.Since(start))
+
+	var pe PanosError
+	if errors.As(err, &pe) {
+		args = append(args, "code", pe.Code)
+	} else if len(body) > 0 {
+		var status panosStatus
+		if xml.Unmarshal(body, &status) == nil && status.ResponseCode != 0 {
+			args = append(args, "code", status.ResponseCode)
+		}
+	}
+
+	if err != nil {
+		args = append(args, "error", err)
+		c.Logger.Warn("api call failed", args...)
+		return
+	}
+
+	var job util.JobResponse
+	if xml.Unmarshal(body, &job) == nil && job.Id != 0 {
+		args = append(args, "job", job.Id)
+	}
+	c.Logger.Debug("api call", args...)
+}
+
+// xpathEntryName returns the name of the entry directly beneath the given
+// node in the xpath, if present.
+func xpathEntryName(xp, node string) string {
+	prefix := fmt.Sprintf("/%s/entry[@name='", node)
+	idx := strings.Index(xp, prefix)
+	if idx == -1 {
+		return ""
+	}
+
+	rest := xp[idx+len(prefix):]
+	if end := strings.Index(rest, "'"); end != -1 {
+		return rest[:end]
+	}
+
+	return ""
+}
@@ -0,0 +1,154 @@
+package pango
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type testLogger struct {
+	records []string
+}
+
+func (o *testLogger) add(level, msg string, args ...interface{}) {
+	var buf bytes.Buffer
+	buf.WriteString(level)
+	buf.WriteString(" ")
+	buf.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i] == "duration" {
+			continue
+		}
+		fmt.Fprintf(&buf, " %v=%v", args[i], args[i+1])
+	}
+	o.records = append(o.records, buf.String())
+}
+
+func (o *testLogger) Debug(msg string, args ...interface{}) { o.add("DEBUG", msg, args...) }
+func (o *testLogger) Info(msg string, args ...interface{})  { o.add("INFO", msg, args...) }
+func (o *testLogger) Warn(msg string, args ...interface{})  { o.add("WARN", msg, args...) }
+func (o *testLogger) Error(msg string, args ...interface{}) { o.add("ERROR", msg, args...) }
+
+func TestRedactXml(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		out  string
+	}{
+		{"password", "<password>hunter2</password>", "<password>########</password>"},
+		{"phash", `<phash>$1$abc</phash><name>admin</name>`, "<phash>########</phash><name>admin</name>"},
+		{"psk", "<pre-shared-key><key>s3cret</key></pre-shared-key>", "<pre-shared-key><key>########</key></pre-shared-key>"},
+		{"cdata", "<auth-password><![CDATA[x<y]]></auth-password>", "<auth-password>########</auth-password>"},
+		{"community", `<community attr="a">public</community>`, `<community attr="a">########</community>`},
+		{"unrelated", "<monkey>banana</monkey><description>key</description>", "<monkey>banana</monkey><description>key</description>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if ans := RedactXml(tc.in); ans != tc.out {
+				t.Errorf("Expected %q, got %q", tc.out, ans)
+			}
+		})
+	}
+}
+
+func TestLogSendRedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer rl()
+
+	c := &Client{Logging: LogSend}
+	c.setResponses([][]byte{[]byte("<response status=\"success\" />")})
+	data := url.Values{}
+	data.Set("user", "admin")
+	data.Set("password", "hunter2")
+	data.Set("element", "<entry name='admin'><phash>abc123</phash></entry>")
+	if _, err := c.Communicate(data, nil); err != nil {
+		t.Errorf("Error in communicate: %s", err)
+	}
+
+	s := buf.String()
+	if strings.Contains(s, "hunter2") || strings.Contains(s, "abc123") {
+		t.Errorf("Secrets were logged: %s", s)
+	} else if !strings.Contains(s, "admin") {
+		t.Errorf("Expected non-secrets to be logged: %s", s)
+	}
+	if data.Get("password") != "hunter2" {
+		t.Errorf("Sent data was modified")
+	}
+}
+
+func TestLogSendRedactsImportParams(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer rl()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<response status="success"><result /></response>`))
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	c := &Client{
+		Hostname: u.Host,
+		Protocol: "http",
+		ApiKey:   "s3cretkey",
+		Logging:  LogSend,
+	}
+	if err := c.initCon(); err != nil {
+		t.Fatalf("initCon failed: %s", err)
+	}
+
+	extras := map[string]string{
+		"certificate-name": "mycert",
+		"format":           "pkcs12",
+		"passphrase":       "hunter2",
+	}
+	if _, err := c.Import("keypair", "content", "mycert.p12", "file", extras, nil); err != nil {
+		t.Fatalf("Error in import: %s", err)
+	}
+
+	s := buf.String()
+	if strings.Contains(s, "hunter2") || strings.Contains(s, "s3cretkey") {
+		t.Errorf("Secrets were logged: %s", s)
+	} else if !strings.Contains(s, "mycert") {
+		t.Errorf("Expected non-secrets to be logged: %s", s)
+	}
+}
+
+func TestLoggerReceivesStructuredCalls(t *testing.T) {
+	tl := &testLogger{}
+	c := &Client{Logging: LogAction, Logger: tl}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success" code="20"><msg>command succeeded</msg></response>`),
+		[]byte(`<response status="error" code="7"><msg><line>No such node</line></msg></response>`),
+		[]byte(`<response status="success"><result><job>9</job></result></response>`),
+	})
+
+	c.LogAction("(set) %s", "foo")
+	c.Set("/config/devices/entry[@name='localhost.localdomain']/device-group/entry[@name='dg1']/address", "<entry name='foo' />", nil, nil)
+	c.Delete("/config/devices/entry[@name='localhost.localdomain']/template/entry[@name='t1']/config", nil, nil)
+	c.Commit("", nil, true, true, false, false)
+
+	expected := []string{
+		"INFO (set) foo category=action",
+		"DEBUG api call operation=config/set xpath=/config/devices/entry[@name='localhost.localdomain']/device-group/entry[@name='dg1']/address device_group=dg1 code=20",
+		"WARN api call failed operation=config/delete xpath=/config/devices/entry[@name='localhost.localdomain']/template/entry[@name='t1']/config template=t1 code=7 error=No such node",
+		"INFO (commit) \"\" category=action",
+		"DEBUG api call operation=commit job=9",
+	}
+
+	if len(tl.records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d: %#v", len(expected), len(tl.records), tl.records)
+	}
+	for i := range expected {
+		if tl.records[i] != expected[i] {
+			t.Errorf("Record %d:\nexpected %s\n     got %s", i, expected[i], tl.records[i])
+		}
+	}
+}
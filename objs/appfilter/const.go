@@ -0,0 +1,6 @@
+package appfilter
+
+const (
+    singular = "application filter"
+    plural = "application filters"
+)
@@ -0,0 +1,6 @@
+/*
+Package appfilter is the client.Objects.AppFilter namespace.
+
+Normalized object:  Entry
+*/
+package appfilter
@@ -0,0 +1,188 @@
+package appfilter
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of an
+// application filter.
+//
+// Risks are the risk levels to match, "1" through "5".  The boolean params
+// are application characteristics; setting one to true limits the filter to
+// applications that have that characteristic.
+//
+// Tags requires PAN-OS 9.0+.
+type Entry struct {
+    Name string
+    Categories []string // unordered
+    Subcategories []string // unordered
+    Technologies []string // unordered
+    Risks []string // unordered
+    AbleToFileTransfer bool
+    ExcessiveBandwidth bool
+    TunnelsOtherApplications bool
+    HasKnownVulnerability bool
+    UsedByMalware bool
+    EvasiveBehavior bool
+    PervasiveUse bool
+    ProneToMisuse bool
+    Tags []string // unordered
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Categories = s.Categories
+    o.Subcategories = s.Subcategories
+    o.Technologies = s.Technologies
+    o.Risks = s.Risks
+    o.AbleToFileTransfer = s.AbleToFileTransfer
+    o.ExcessiveBandwidth = s.ExcessiveBandwidth
+    o.TunnelsOtherApplications = s.TunnelsOtherApplications
+    o.HasKnownVulnerability = s.HasKnownVulnerability
+    o.UsedByMalware = s.UsedByMalware
+    o.EvasiveBehavior = s.EvasiveBehavior
+    o.PervasiveUse = s.PervasiveUse
+    o.ProneToMisuse = s.ProneToMisuse
+    o.Tags = s.Tags
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+// base is the part of the application filter common to all versions.
+//
+// The characteristics only accept "yes", so they are omitted if false.
+type base struct {
+    Categories *util.MemberType `xml:"category"`
+    Subcategories *util.MemberType `xml:"subcategory"`
+    Technologies *util.MemberType `xml:"technology"`
+    Risks *util.MemberType `xml:"risk"`
+    AbleToFileTransfer string `xml:"transfers-files,omitempty"`
+    ExcessiveBandwidth string `xml:"excessive-bandwidth-use,omitempty"`
+    TunnelsOtherApplications string `xml:"tunnels-other-apps,omitempty"`
+    HasKnownVulnerability string `xml:"has-known-vulnerabilities,omitempty"`
+    UsedByMalware string `xml:"used-by-malware,omitempty"`
+    EvasiveBehavior string `xml:"evasive,omitempty"`
+    PervasiveUse string `xml:"pervasive,omitempty"`
+    ProneToMisuse string `xml:"prone-to-misuse,omitempty"`
+}
+
+func (o *base) normalize(e *Entry) {
+    e.Categories = util.MemToStr(o.Categories)
+    e.Subcategories = util.MemToStr(o.Subcategories)
+    e.Technologies = util.MemToStr(o.Technologies)
+    e.Risks = util.MemToStr(o.Risks)
+    e.AbleToFileTransfer = util.AsBool(o.AbleToFileTransfer)
+    e.ExcessiveBandwidth = util.AsBool(o.ExcessiveBandwidth)
+    e.TunnelsOtherApplications = util.AsBool(o.TunnelsOtherApplications)
+    e.HasKnownVulnerability = util.AsBool(o.HasKnownVulnerability)
+    e.UsedByMalware = util.AsBool(o.UsedByMalware)
+    e.EvasiveBehavior = util.AsBool(o.EvasiveBehavior)
+    e.PervasiveUse = util.AsBool(o.PervasiveUse)
+    e.ProneToMisuse = util.AsBool(o.ProneToMisuse)
+}
+
+func specifyBase(e Entry) base {
+    return base{
+        Categories: util.StrToMem(e.Categories),
+        Subcategories: util.StrToMem(e.Subcategories),
+        Technologies: util.StrToMem(e.Technologies),
+        Risks: util.StrToMem(e.Risks),
+        AbleToFileTransfer: yesOnly(e.AbleToFileTransfer),
+        ExcessiveBandwidth: yesOnly(e.ExcessiveBandwidth),
+        TunnelsOtherApplications: yesOnly(e.TunnelsOtherApplications),
+        HasKnownVulnerability: yesOnly(e.HasKnownVulnerability),
+        UsedByMalware: yesOnly(e.UsedByMalware),
+        EvasiveBehavior: yesOnly(e.EvasiveBehavior),
+        PervasiveUse: yesOnly(e.PervasiveUse),
+        ProneToMisuse: yesOnly(e.ProneToMisuse),
+    }
+}
+
+func yesOnly(v bool) string {
+    if v {
+        return "yes"
+    }
+
+    return ""
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+    }
+
+    o.Answer.base.normalize(&ans)
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    base
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        base: specifyBase(e),
+    }
+
+    return ans
+}
+
+// PAN-OS 9.0+: tagging added.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+    }
+
+    o.Answer.base.normalize(&ans)
+    if o.Answer.Tagging != nil {
+        ans.Tags = util.MemToStr(o.Answer.Tagging.Tags)
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    base
+    Tagging *tagging `xml:"tagging"`
+}
+
+type tagging struct {
+    Tags *util.MemberType `xml:"tag"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        base: specifyBase(e),
+    }
+
+    if len(e.Tags) > 0 {
+        ans.Tagging = &tagging{
+            Tags: util.StrToMem(e.Tags),
+        }
+    }
+
+    return ans
+}
@@ -0,0 +1,44 @@
+package appfilter
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t1",
+            Categories: []string{"business-systems", "networking"},
+            Subcategories: []string{"database"},
+            Technologies: []string{"client-server"},
+            Risks: []string{"4", "5"},
+        }},
+        {"v1 characteristics", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t2",
+            AbleToFileTransfer: true,
+            ExcessiveBandwidth: true,
+            TunnelsOtherApplications: true,
+            HasKnownVulnerability: true,
+            UsedByMalware: true,
+            EvasiveBehavior: true,
+            PervasiveUse: true,
+            ProneToMisuse: true,
+        }},
+        {"v2 basic", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t3",
+            Categories: []string{"media"},
+            EvasiveBehavior: true,
+        }},
+        {"v2 with tags", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t4",
+            Risks: []string{"3"},
+            Tags: []string{"Enterprise VoIP", "Web App"},
+        }},
+    }
+}
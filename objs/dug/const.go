@@ -0,0 +1,6 @@
+package dug
+
+const (
+    singular = "dynamic user group"
+    plural = "dynamic user groups"
+)
@@ -0,0 +1,6 @@
+/*
+Package dug is the client.Objects.DynamicUserGroup namespace.
+
+Normalized object:  Entry
+*/
+package dug
@@ -0,0 +1,74 @@
+package dug
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a dynamic
+// user group.  The value set in Filter is a tag expression matched against
+// the tags registered to users, and should be something like the following:
+//
+//  * 'tag1'
+//  * 'tag1' or 'tag2' and 'tag3'
+//
+// The Tags param is for administrative tags for this dynamic user group
+// itself.
+//
+// PAN-OS 9.1+.
+type Entry struct {
+    Name string
+    Description string
+    Filter string
+    Tags []string // ordered
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.Filter = s.Filter
+    o.Tags = s.Tags
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        Filter: o.Answer.Filter,
+        Tags: util.MemToStr(o.Answer.Tags),
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Filter string `xml:"filter,omitempty"`
+    Tags *util.MemberType `xml:"tag"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        Filter: e.Filter,
+        Tags: util.StrToMem(e.Tags),
+    }
+
+    return ans
+}
@@ -0,0 +1,26 @@
+package dug
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{9, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+            Filter: "'tag1'",
+        }},
+        {"v1 with tags", version.Number{9, 1, 0, ""}, Entry{
+            Name: "t2",
+            Filter: "'tag1' or 'tag2' and 'tag3'",
+            Tags: []string{"admin1", "admin2"},
+        }},
+    }
+}
@@ -1,6 +1,5 @@
 package objs
 
-
 import (
     "github.com/inwinstack/pango/util"
 
@@ -11,32 +10,57 @@ import (
     "github.com/inwinstack/pango/objs/app/signature"
     "github.com/inwinstack/pango/objs/app/signature/andcond"
     "github.com/inwinstack/pango/objs/app/signature/orcond"
+    "github.com/inwinstack/pango/objs/appfilter"
+    "github.com/inwinstack/pango/objs/dug"
     "github.com/inwinstack/pango/objs/edl"
+    "github.com/inwinstack/pango/objs/profile/datafilter"
+    "github.com/inwinstack/pango/objs/profile/fileblock"
+    "github.com/inwinstack/pango/objs/profile/group"
     "github.com/inwinstack/pango/objs/profile/logfwd"
     "github.com/inwinstack/pango/objs/profile/logfwd/matchlist"
     "github.com/inwinstack/pango/objs/profile/logfwd/matchlist/action"
+    "github.com/inwinstack/pango/objs/profile/spyware"
+    "github.com/inwinstack/pango/objs/profile/urlfilter"
+    "github.com/inwinstack/pango/objs/profile/virus"
+    "github.com/inwinstack/pango/objs/profile/vulnerability"
+    "github.com/inwinstack/pango/objs/profile/wildfire"
+    "github.com/inwinstack/pango/objs/region"
+    "github.com/inwinstack/pango/objs/schedule"
     "github.com/inwinstack/pango/objs/srvc"
     "github.com/inwinstack/pango/objs/srvcgrp"
     "github.com/inwinstack/pango/objs/tags"
+    "github.com/inwinstack/pango/objs/urlcat"
 )
 
-
 // FwObjs is the client.Objects namespace.
 type FwObjs struct {
     Address *addr.FwAddr
     AddressGroup *addrgrp.FwAddrGrp
+    AntiSpywareProfile *spyware.FwSpyware
+    AntivirusProfile *virus.FwVirus
     Application *app.FwApp
+    AppFilter *appfilter.FwAppFilter
     AppGroup *appgrp.FwGroup
     AppSignature *signature.FwSignature
     AppSigAndCond *andcond.FwAndCond
     AppSigAndCondOrCond *orcond.FwOrCond
+    CustomUrlCategory *urlcat.FwUrlCategory
+    DataFilteringProfile *datafilter.FwDataFilter
+    DynamicUserGroup *dug.FwDug
     Edl *edl.FwEdl
+    FileBlockingProfile *fileblock.FwFileBlock
     LogForwardingProfile *logfwd.FwLogFwd
     LogForwardingProfileMatchList *matchlist.FwMatchList
     LogForwardingProfileMatchListAction *action.FwAction
+    Region *region.FwRegion
+    Schedule *schedule.FwSchedule
+    SecurityProfileGroup *group.FwGroup
     Services *srvc.FwSrvc
     ServiceGroup *srvcgrp.FwSrvcGrp
     Tags *tags.FwTags
+    UrlFilteringProfile *urlfilter.FwUrlFilter
+    VulnerabilityProfile *vulnerability.FwVulnerability
+    WildfireAnalysisProfile *wildfire.FwWildfire
 }
 
 // Initialize is invoked on client.Initialize().
@@ -47,9 +71,18 @@ func (c *FwObjs) Initialize(i util.XapiClient) {
     c.AddressGroup = &addrgrp.FwAddrGrp{}
     c.AddressGroup.Initialize(i)
 
+    c.AntiSpywareProfile = &spyware.FwSpyware{}
+    c.AntiSpywareProfile.Initialize(i)
+
+    c.AntivirusProfile = &virus.FwVirus{}
+    c.AntivirusProfile.Initialize(i)
+
     c.Application = &app.FwApp{}
     c.Application.Initialize(i)
 
+    c.AppFilter = &appfilter.FwAppFilter{}
+    c.AppFilter.Initialize(i)
+
     c.AppGroup = &appgrp.FwGroup{}
     c.AppGroup.Initialize(i)
 
@@ -62,9 +95,21 @@ func (c *FwObjs) Initialize(i util.XapiClient) {
     c.AppSigAndCondOrCond = &orcond.FwOrCond{}
     c.AppSigAndCondOrCond.Initialize(i)
 
+    c.CustomUrlCategory = &urlcat.FwUrlCategory{}
+    c.CustomUrlCategory.Initialize(i)
+
+    c.DataFilteringProfile = &datafilter.FwDataFilter{}
+    c.DataFilteringProfile.Initialize(i)
+
+    c.DynamicUserGroup = &dug.FwDug{}
+    c.DynamicUserGroup.Initialize(i)
+
     c.Edl = &edl.FwEdl{}
     c.Edl.Initialize(i)
 
+    c.FileBlockingProfile = &fileblock.FwFileBlock{}
+    c.FileBlockingProfile.Initialize(i)
+
     c.LogForwardingProfile = &logfwd.FwLogFwd{}
     c.LogForwardingProfile.Initialize(i)
 
@@ -74,6 +119,15 @@ func (c *FwObjs) Initialize(i util.XapiClient) {
     c.LogForwardingProfileMatchListAction = &action.FwAction{}
     c.LogForwardingProfileMatchListAction.Initialize(i)
 
+    c.Region = &region.FwRegion{}
+    c.Region.Initialize(i)
+
+    c.Schedule = &schedule.FwSchedule{}
+    c.Schedule.Initialize(i)
+
+    c.SecurityProfileGroup = &group.FwGroup{}
+    c.SecurityProfileGroup.Initialize(i)
+
     c.Services = &srvc.FwSrvc{}
     c.Services.Initialize(i)
 
@@ -82,4 +136,13 @@ func (c *FwObjs) Initialize(i util.XapiClient) {
 
     c.Tags = &tags.FwTags{}
     c.Tags.Initialize(i)
+
+    c.UrlFilteringProfile = &urlfilter.FwUrlFilter{}
+    c.UrlFilteringProfile.Initialize(i)
+
+    c.VulnerabilityProfile = &vulnerability.FwVulnerability{}
+    c.VulnerabilityProfile.Initialize(i)
+
+    c.WildfireAnalysisProfile = &wildfire.FwWildfire{}
+    c.WildfireAnalysisProfile.Initialize(i)
 }
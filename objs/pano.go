@@ -11,13 +11,26 @@ import (
     "github.com/inwinstack/pango/objs/app/signature"
     "github.com/inwinstack/pango/objs/app/signature/andcond"
     "github.com/inwinstack/pango/objs/app/signature/orcond"
+    "github.com/inwinstack/pango/objs/appfilter"
+    "github.com/inwinstack/pango/objs/dug"
     "github.com/inwinstack/pango/objs/edl"
+    "github.com/inwinstack/pango/objs/profile/datafilter"
+    "github.com/inwinstack/pango/objs/profile/fileblock"
+    "github.com/inwinstack/pango/objs/profile/group"
     "github.com/inwinstack/pango/objs/profile/logfwd"
     "github.com/inwinstack/pango/objs/profile/logfwd/matchlist"
     "github.com/inwinstack/pango/objs/profile/logfwd/matchlist/action"
+    "github.com/inwinstack/pango/objs/profile/spyware"
+    "github.com/inwinstack/pango/objs/profile/urlfilter"
+    "github.com/inwinstack/pango/objs/profile/virus"
+    "github.com/inwinstack/pango/objs/profile/vulnerability"
+    "github.com/inwinstack/pango/objs/profile/wildfire"
+    "github.com/inwinstack/pango/objs/region"
+    "github.com/inwinstack/pango/objs/schedule"
     "github.com/inwinstack/pango/objs/srvc"
     "github.com/inwinstack/pango/objs/srvcgrp"
     "github.com/inwinstack/pango/objs/tags"
+    "github.com/inwinstack/pango/objs/urlcat"
 )
 
 
@@ -25,18 +38,31 @@ import (
 type PanoObjs struct {
     Address *addr.PanoAddr
     AddressGroup *addrgrp.PanoAddrGrp
+    AntiSpywareProfile *spyware.PanoSpyware
+    AntivirusProfile *virus.PanoVirus
     Application *app.PanoApp
+    AppFilter *appfilter.PanoAppFilter
     AppGroup *appgrp.PanoGroup
     AppSignature *signature.PanoSignature
     AppSigAndCond *andcond.PanoAndCond
     AppSigOrCond *orcond.PanoOrCond
+    CustomUrlCategory *urlcat.PanoUrlCategory
+    DataFilteringProfile *datafilter.PanoDataFilter
+    DynamicUserGroup *dug.PanoDug
     Edl *edl.PanoEdl
+    FileBlockingProfile *fileblock.PanoFileBlock
     LogForwardingProfile *logfwd.PanoLogFwd
     LogForwardingProfileMatchList *matchlist.PanoMatchList
     LogForwardingProfileMatchListAction *action.PanoAction
+    Region *region.PanoRegion
+    Schedule *schedule.PanoSchedule
+    SecurityProfileGroup *group.PanoGroup
     Services *srvc.PanoSrvc
     ServiceGroup *srvcgrp.PanoSrvcGrp
     Tags *tags.PanoTags
+    UrlFilteringProfile *urlfilter.PanoUrlFilter
+    VulnerabilityProfile *vulnerability.PanoVulnerability
+    WildfireAnalysisProfile *wildfire.PanoWildfire
 }
 
 // Initialize is invoked on client.Initialize().
@@ -47,9 +73,18 @@ func (c *PanoObjs) Initialize(i util.XapiClient) {
     c.AddressGroup = &addrgrp.PanoAddrGrp{}
     c.AddressGroup.Initialize(i)
 
+    c.AntiSpywareProfile = &spyware.PanoSpyware{}
+    c.AntiSpywareProfile.Initialize(i)
+
+    c.AntivirusProfile = &virus.PanoVirus{}
+    c.AntivirusProfile.Initialize(i)
+
     c.Application = &app.PanoApp{}
     c.Application.Initialize(i)
 
+    c.AppFilter = &appfilter.PanoAppFilter{}
+    c.AppFilter.Initialize(i)
+
     c.AppGroup = &appgrp.PanoGroup{}
     c.AppGroup.Initialize(i)
 
@@ -62,9 +97,21 @@ func (c *PanoObjs) Initialize(i util.XapiClient) {
     c.AppSigOrCond = &orcond.PanoOrCond{}
     c.AppSigOrCond.Initialize(i)
 
+    c.CustomUrlCategory = &urlcat.PanoUrlCategory{}
+    c.CustomUrlCategory.Initialize(i)
+
+    c.DataFilteringProfile = &datafilter.PanoDataFilter{}
+    c.DataFilteringProfile.Initialize(i)
+
+    c.DynamicUserGroup = &dug.PanoDug{}
+    c.DynamicUserGroup.Initialize(i)
+
     c.Edl = &edl.PanoEdl{}
     c.Edl.Initialize(i)
 
+    c.FileBlockingProfile = &fileblock.PanoFileBlock{}
+    c.FileBlockingProfile.Initialize(i)
+
     c.LogForwardingProfile = &logfwd.PanoLogFwd{}
     c.LogForwardingProfile.Initialize(i)
 
@@ -74,6 +121,15 @@ func (c *PanoObjs) Initialize(i util.XapiClient) {
     c.LogForwardingProfileMatchListAction = &action.PanoAction{}
     c.LogForwardingProfileMatchListAction.Initialize(i)
 
+    c.Region = &region.PanoRegion{}
+    c.Region.Initialize(i)
+
+    c.Schedule = &schedule.PanoSchedule{}
+    c.Schedule.Initialize(i)
+
+    c.SecurityProfileGroup = &group.PanoGroup{}
+    c.SecurityProfileGroup.Initialize(i)
+
     c.Services = &srvc.PanoSrvc{}
     c.Services.Initialize(i)
 
@@ -82,4 +138,13 @@ func (c *PanoObjs) Initialize(i util.XapiClient) {
 
     c.Tags = &tags.PanoTags{}
     c.Tags.Initialize(i)
+
+    c.UrlFilteringProfile = &urlfilter.PanoUrlFilter{}
+    c.UrlFilteringProfile.Initialize(i)
+
+    c.VulnerabilityProfile = &vulnerability.PanoVulnerability{}
+    c.VulnerabilityProfile.Initialize(i)
+
+    c.WildfireAnalysisProfile = &wildfire.PanoWildfire{}
+    c.WildfireAnalysisProfile.Initialize(i)
 }
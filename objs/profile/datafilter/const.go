@@ -0,0 +1,13 @@
+package datafilter
+
+// Valid values for Rule.Direction.
+const (
+    DirectionUpload = "upload"
+    DirectionDownload = "download"
+    DirectionBoth = "both"
+)
+
+const (
+    singular = "data filtering profile"
+    plural = "data filtering profiles"
+)
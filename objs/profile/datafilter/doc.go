@@ -0,0 +1,6 @@
+/*
+Package datafilter is the client.Objects.DataFilteringProfile namespace.
+
+Normalized object:  Entry
+*/
+package datafilter
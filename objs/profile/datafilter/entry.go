@@ -0,0 +1,204 @@
+package datafilter
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a data
+// filtering security profile.
+type Entry struct {
+    Name string
+    Description string
+    DataCapture bool
+    Rules []Rule
+}
+
+// Rule is a single rule in a data filtering profile.
+//
+// DataPattern is the name of the data pattern object to match.  LogSeverity
+// requires PAN-OS 8.0+.
+type Rule struct {
+    Name string
+    DataPattern string
+    Applications []string
+    FileTypes []string
+    Direction string
+    AlertThreshold int
+    BlockThreshold int
+    LogSeverity string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.DataCapture = s.DataCapture
+    o.Rules = s.Rules
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        DataCapture: util.AsBool(o.Answer.DataCapture),
+    }
+
+    if o.Answer.Rules != nil {
+        ans.Rules = make([]Rule, 0, len(o.Answer.Rules.Entries))
+        for _, r := range o.Answer.Rules.Entries {
+            ans.Rules = append(ans.Rules, Rule{
+                Name: r.Name,
+                DataPattern: r.DataPattern,
+                Applications: util.MemToStr(r.Applications),
+                FileTypes: util.MemToStr(r.FileTypes),
+                Direction: r.Direction,
+                AlertThreshold: r.AlertThreshold,
+                BlockThreshold: r.BlockThreshold,
+            })
+        }
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    DataCapture string `xml:"data-capture"`
+    Rules *rules_v1 `xml:"rules"`
+}
+
+type rules_v1 struct {
+    Entries []rule_v1 `xml:"entry"`
+}
+
+type rule_v1 struct {
+    Name string `xml:"name,attr"`
+    DataPattern string `xml:"data-object,omitempty"`
+    Applications *util.MemberType `xml:"application"`
+    FileTypes *util.MemberType `xml:"file-type"`
+    Direction string `xml:"direction,omitempty"`
+    AlertThreshold int `xml:"alert-threshold,omitempty"`
+    BlockThreshold int `xml:"block-threshold,omitempty"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        DataCapture: util.YesNo(e.DataCapture),
+    }
+
+    if len(e.Rules) > 0 {
+        list := make([]rule_v1, 0, len(e.Rules))
+        for _, r := range e.Rules {
+            list = append(list, rule_v1{
+                Name: r.Name,
+                DataPattern: r.DataPattern,
+                Applications: util.StrToMem(r.Applications),
+                FileTypes: util.StrToMem(r.FileTypes),
+                Direction: r.Direction,
+                AlertThreshold: r.AlertThreshold,
+                BlockThreshold: r.BlockThreshold,
+            })
+        }
+        ans.Rules = &rules_v1{Entries: list}
+    }
+
+    return ans
+}
+
+// PAN-OS 8.0+: rules have a log severity.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        DataCapture: util.AsBool(o.Answer.DataCapture),
+    }
+
+    if o.Answer.Rules != nil {
+        ans.Rules = make([]Rule, 0, len(o.Answer.Rules.Entries))
+        for _, r := range o.Answer.Rules.Entries {
+            ans.Rules = append(ans.Rules, Rule{
+                Name: r.Name,
+                DataPattern: r.DataPattern,
+                Applications: util.MemToStr(r.Applications),
+                FileTypes: util.MemToStr(r.FileTypes),
+                Direction: r.Direction,
+                AlertThreshold: r.AlertThreshold,
+                BlockThreshold: r.BlockThreshold,
+                LogSeverity: r.LogSeverity,
+            })
+        }
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    DataCapture string `xml:"data-capture"`
+    Rules *rules_v2 `xml:"rules"`
+}
+
+type rules_v2 struct {
+    Entries []rule_v2 `xml:"entry"`
+}
+
+type rule_v2 struct {
+    Name string `xml:"name,attr"`
+    DataPattern string `xml:"data-object,omitempty"`
+    Applications *util.MemberType `xml:"application"`
+    FileTypes *util.MemberType `xml:"file-type"`
+    Direction string `xml:"direction,omitempty"`
+    AlertThreshold int `xml:"alert-threshold,omitempty"`
+    BlockThreshold int `xml:"block-threshold,omitempty"`
+    LogSeverity string `xml:"log-severity,omitempty"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        Description: e.Description,
+        DataCapture: util.YesNo(e.DataCapture),
+    }
+
+    if len(e.Rules) > 0 {
+        list := make([]rule_v2, 0, len(e.Rules))
+        for _, r := range e.Rules {
+            list = append(list, rule_v2{
+                Name: r.Name,
+                DataPattern: r.DataPattern,
+                Applications: util.StrToMem(r.Applications),
+                FileTypes: util.StrToMem(r.FileTypes),
+                Direction: r.Direction,
+                AlertThreshold: r.AlertThreshold,
+                BlockThreshold: r.BlockThreshold,
+                LogSeverity: r.LogSeverity,
+            })
+        }
+        ans.Rules = &rules_v2{Entries: list}
+    }
+
+    return ans
+}
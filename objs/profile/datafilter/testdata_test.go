@@ -0,0 +1,55 @@
+package datafilter
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+            DataCapture: true,
+        }},
+        {"v1 with rules", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    DataPattern: "ssn",
+                    Applications: []string{"any"},
+                    FileTypes: []string{"any"},
+                    Direction: DirectionBoth,
+                    AlertThreshold: 1,
+                    BlockThreshold: 5,
+                },
+            },
+        }},
+        {"v2 basic", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t3",
+            Description: "my description",
+        }},
+        {"v2 with rules", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t4",
+            DataCapture: true,
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    DataPattern: "ssn",
+                    Applications: []string{"web-browsing"},
+                    FileTypes: []string{"pdf", "docx"},
+                    Direction: DirectionUpload,
+                    AlertThreshold: 2,
+                    BlockThreshold: 10,
+                    LogSeverity: "high",
+                },
+            },
+        }},
+    }
+}
@@ -0,0 +1,20 @@
+package fileblock
+
+// Valid values for Rule.Direction.
+const (
+    DirectionUpload = "upload"
+    DirectionDownload = "download"
+    DirectionBoth = "both"
+)
+
+// Valid values for Rule.Action.
+const (
+    ActionAlert = "alert"
+    ActionBlock = "block"
+    ActionContinue = "continue"
+)
+
+const (
+    singular = "file blocking profile"
+    plural = "file blocking profiles"
+)
@@ -0,0 +1,6 @@
+/*
+Package fileblock is the client.Objects.FileBlockingProfile namespace.
+
+Normalized object:  Entry
+*/
+package fileblock
@@ -0,0 +1,106 @@
+package fileblock
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a file
+// blocking security profile.
+type Entry struct {
+    Name string
+    Description string
+    Rules []Rule
+}
+
+// Rule is a single rule in a file blocking profile.
+type Rule struct {
+    Name string
+    Applications []string
+    FileTypes []string
+    Direction string
+    Action string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.Rules = s.Rules
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+    }
+
+    if o.Answer.Rules != nil {
+        ans.Rules = make([]Rule, 0, len(o.Answer.Rules.Entries))
+        for _, r := range o.Answer.Rules.Entries {
+            ans.Rules = append(ans.Rules, Rule{
+                Name: r.Name,
+                Applications: util.MemToStr(r.Applications),
+                FileTypes: util.MemToStr(r.FileTypes),
+                Direction: r.Direction,
+                Action: r.Action,
+            })
+        }
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Rules *rules `xml:"rules"`
+}
+
+type rules struct {
+    Entries []rule `xml:"entry"`
+}
+
+type rule struct {
+    Name string `xml:"name,attr"`
+    Applications *util.MemberType `xml:"application"`
+    FileTypes *util.MemberType `xml:"file-type"`
+    Direction string `xml:"direction,omitempty"`
+    Action string `xml:"action,omitempty"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+    }
+
+    if len(e.Rules) > 0 {
+        list := make([]rule, 0, len(e.Rules))
+        for _, r := range e.Rules {
+            list = append(list, rule{
+                Name: r.Name,
+                Applications: util.StrToMem(r.Applications),
+                FileTypes: util.StrToMem(r.FileTypes),
+                Direction: r.Direction,
+                Action: r.Action,
+            })
+        }
+        ans.Rules = &rules{Entries: list}
+    }
+
+    return ans
+}
@@ -0,0 +1,39 @@
+package fileblock
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+        }},
+        {"v1 with rules", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    Applications: []string{"any"},
+                    FileTypes: []string{"exe", "dll"},
+                    Direction: DirectionBoth,
+                    Action: ActionBlock,
+                },
+                Rule{
+                    Name: "r2",
+                    Applications: []string{"web-browsing", "ssl"},
+                    FileTypes: []string{"pdf"},
+                    Direction: DirectionDownload,
+                    Action: ActionContinue,
+                },
+            },
+        }},
+    }
+}
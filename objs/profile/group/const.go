@@ -0,0 +1,6 @@
+package group
+
+const (
+    singular = "security profile group"
+    plural = "security profile groups"
+)
@@ -0,0 +1,6 @@
+/*
+Package group is the client.Objects.SecurityProfileGroup namespace.
+
+Normalized object:  Entry
+*/
+package group
@@ -0,0 +1,88 @@
+package group
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a security
+// profile group.
+//
+// Each field is the name of the security profile of that type to include in
+// this group.
+type Entry struct {
+    Name string
+    Virus string
+    Spyware string
+    Vulnerability string
+    UrlFiltering string
+    FileBlocking string
+    WildFireAnalysis string
+    DataFiltering string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Virus = s.Virus
+    o.Spyware = s.Spyware
+    o.Vulnerability = s.Vulnerability
+    o.UrlFiltering = s.UrlFiltering
+    o.FileBlocking = s.FileBlocking
+    o.WildFireAnalysis = s.WildFireAnalysis
+    o.DataFiltering = s.DataFiltering
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Virus: util.MemToOneStr(o.Answer.Virus),
+        Spyware: util.MemToOneStr(o.Answer.Spyware),
+        Vulnerability: util.MemToOneStr(o.Answer.Vulnerability),
+        UrlFiltering: util.MemToOneStr(o.Answer.UrlFiltering),
+        FileBlocking: util.MemToOneStr(o.Answer.FileBlocking),
+        WildFireAnalysis: util.MemToOneStr(o.Answer.WildFireAnalysis),
+        DataFiltering: util.MemToOneStr(o.Answer.DataFiltering),
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Virus *util.MemberType `xml:"virus"`
+    Spyware *util.MemberType `xml:"spyware"`
+    Vulnerability *util.MemberType `xml:"vulnerability"`
+    UrlFiltering *util.MemberType `xml:"url-filtering"`
+    FileBlocking *util.MemberType `xml:"file-blocking"`
+    WildFireAnalysis *util.MemberType `xml:"wildfire-analysis"`
+    DataFiltering *util.MemberType `xml:"data-filtering"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Virus: util.OneStrToMem(e.Virus),
+        Spyware: util.OneStrToMem(e.Spyware),
+        Vulnerability: util.OneStrToMem(e.Vulnerability),
+        UrlFiltering: util.OneStrToMem(e.UrlFiltering),
+        FileBlocking: util.OneStrToMem(e.FileBlocking),
+        WildFireAnalysis: util.OneStrToMem(e.WildFireAnalysis),
+        DataFiltering: util.OneStrToMem(e.DataFiltering),
+    }
+
+    return ans
+}
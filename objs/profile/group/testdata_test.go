@@ -0,0 +1,34 @@
+package group
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 empty group", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+        }},
+        {"v1 some profiles", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Virus: "default",
+            Spyware: "strict",
+        }},
+        {"v1 all profiles", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t3",
+            Virus: "av",
+            Spyware: "as",
+            Vulnerability: "vp",
+            UrlFiltering: "url",
+            FileBlocking: "fb",
+            WildFireAnalysis: "wf",
+            DataFiltering: "df",
+        }},
+    }
+}
@@ -0,0 +1,39 @@
+package spyware
+
+// Valid values for Rule.Action and ThreatException.Action.
+const (
+    ActionDefault = "default"
+    ActionAllow = "allow"
+    ActionAlert = "alert"
+    ActionDrop = "drop"
+    ActionResetClient = "reset-client"
+    ActionResetServer = "reset-server"
+    ActionResetBoth = "reset-both"
+    ActionBlockIp = "block-ip"
+)
+
+// Valid values for BotnetList.Action.
+const (
+    ListActionAlert = "alert"
+    ListActionAllow = "allow"
+    ListActionBlock = "block"
+    ListActionSinkhole = "sinkhole"
+)
+
+// Valid values for BlockIpTrackBy.
+const (
+    TrackBySource = "source"
+    TrackBySourceAndDestination = "source-and-destination"
+)
+
+// Valid values for PacketCapture.
+const (
+    PacketCaptureDisable = "disable"
+    PacketCaptureSinglePacket = "single-packet"
+    PacketCaptureExtendedCapture = "extended-capture"
+)
+
+const (
+    singular = "anti-spyware profile"
+    plural = "anti-spyware profiles"
+)
@@ -0,0 +1,6 @@
+/*
+Package spyware is the client.Objects.AntiSpywareProfile namespace.
+
+Normalized object:  Entry
+*/
+package spyware
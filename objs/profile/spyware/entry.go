@@ -0,0 +1,410 @@
+package spyware
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of an
+// anti-spyware security profile.
+//
+// BotnetLists are the DNS signature lists and the action taken on DNS queries
+// that match them, while DnsThreatExceptions are the DNS threat IDs that
+// are excluded from those lists.
+type Entry struct {
+    Name string
+    Description string
+    Rules []Rule
+    ThreatExceptions []ThreatException
+    BotnetLists []BotnetList
+    DnsThreatExceptions []string
+    SinkholeIpv4Address string
+    SinkholeIpv6Address string
+}
+
+// Rule is a single anti-spyware rule.
+//
+// BlockIpTrackBy and BlockIpDuration are only used if Action is
+// ActionBlockIp.
+type Rule struct {
+    Name string
+    ThreatName string
+    Category string
+    Severities []string
+    PacketCapture string
+    Action string
+    BlockIpTrackBy string
+    BlockIpDuration int
+}
+
+// ThreatException overrides the action for the given threat ID.
+type ThreatException struct {
+    Name string
+    PacketCapture string
+    Action string
+    BlockIpTrackBy string
+    BlockIpDuration int
+    ExemptIps []string
+}
+
+// BotnetList is a DNS signature list.
+//
+// PacketCapture requires PAN-OS 8.0+.
+type BotnetList struct {
+    Name string
+    Action string
+    PacketCapture string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.Rules = s.Rules
+    o.ThreatExceptions = s.ThreatExceptions
+    o.BotnetLists = s.BotnetLists
+    o.DnsThreatExceptions = s.DnsThreatExceptions
+    o.SinkholeIpv4Address = s.SinkholeIpv4Address
+    o.SinkholeIpv6Address = s.SinkholeIpv6Address
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        Rules: o.Answer.Rules.normalize(),
+        ThreatExceptions: o.Answer.ThreatExceptions.normalize(),
+    }
+
+    if o.Answer.Botnet != nil {
+        if o.Answer.Botnet.Lists != nil {
+            ans.BotnetLists = make([]BotnetList, 0, len(o.Answer.Botnet.Lists.Entries))
+            for _, x := range o.Answer.Botnet.Lists.Entries {
+                act, _, _ := x.Action.normalize()
+                ans.BotnetLists = append(ans.BotnetLists, BotnetList{
+                    Name: x.Name,
+                    Action: act,
+                })
+            }
+        }
+        ans.DnsThreatExceptions = o.Answer.Botnet.ThreatExceptions.normalize()
+        if o.Answer.Botnet.Sinkhole != nil {
+            ans.SinkholeIpv4Address = o.Answer.Botnet.Sinkhole.Ipv4Address
+            ans.SinkholeIpv6Address = o.Answer.Botnet.Sinkhole.Ipv6Address
+        }
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Rules *rules `xml:"rules"`
+    ThreatExceptions *threatExceptions `xml:"threat-exception"`
+    Botnet *botnet_v1 `xml:"botnet-domains"`
+}
+
+type rules struct {
+    Entries []rule `xml:"entry"`
+}
+
+func (o *rules) normalize() []Rule {
+    if o == nil {
+        return nil
+    }
+
+    ans := make([]Rule, 0, len(o.Entries))
+    for _, x := range o.Entries {
+        r := Rule{
+            Name: x.Name,
+            ThreatName: x.ThreatName,
+            Category: x.Category,
+            Severities: util.MemToStr(x.Severities),
+            PacketCapture: x.PacketCapture,
+        }
+        r.Action, r.BlockIpTrackBy, r.BlockIpDuration = x.Action.normalize()
+        ans = append(ans, r)
+    }
+
+    return ans
+}
+
+type rule struct {
+    Name string `xml:"name,attr"`
+    ThreatName string `xml:"threat-name,omitempty"`
+    Category string `xml:"category,omitempty"`
+    Severities *util.MemberType `xml:"severity"`
+    PacketCapture string `xml:"packet-capture,omitempty"`
+    Action *action `xml:"action"`
+}
+
+func specifyRules(list []Rule) *rules {
+    if len(list) == 0 {
+        return nil
+    }
+
+    ans := make([]rule, 0, len(list))
+    for _, x := range list {
+        ans = append(ans, rule{
+            Name: x.Name,
+            ThreatName: x.ThreatName,
+            Category: x.Category,
+            Severities: util.StrToMem(x.Severities),
+            PacketCapture: x.PacketCapture,
+            Action: specifyAction(x.Action, x.BlockIpTrackBy, x.BlockIpDuration),
+        })
+    }
+
+    return &rules{Entries: ans}
+}
+
+type threatExceptions struct {
+    Entries []threatException `xml:"entry"`
+}
+
+func (o *threatExceptions) normalize() []ThreatException {
+    if o == nil {
+        return nil
+    }
+
+    ans := make([]ThreatException, 0, len(o.Entries))
+    for _, x := range o.Entries {
+        te := ThreatException{
+            Name: x.Name,
+            PacketCapture: x.PacketCapture,
+        }
+        te.Action, te.BlockIpTrackBy, te.BlockIpDuration = x.Action.normalize()
+        if x.ExemptIps != nil {
+            te.ExemptIps = make([]string, 0, len(x.ExemptIps.Entries))
+            for _, ip := range x.ExemptIps.Entries {
+                te.ExemptIps = append(te.ExemptIps, ip.Name)
+            }
+        }
+        ans = append(ans, te)
+    }
+
+    return ans
+}
+
+type threatException struct {
+    Name string `xml:"name,attr"`
+    PacketCapture string `xml:"packet-capture,omitempty"`
+    Action *action `xml:"action"`
+    ExemptIps *names `xml:"exempt-ip"`
+}
+
+func specifyThreatExceptions(list []ThreatException) *threatExceptions {
+    if len(list) == 0 {
+        return nil
+    }
+
+    ans := make([]threatException, 0, len(list))
+    for _, x := range list {
+        ans = append(ans, threatException{
+            Name: x.Name,
+            PacketCapture: x.PacketCapture,
+            Action: specifyAction(x.Action, x.BlockIpTrackBy, x.BlockIpDuration),
+            ExemptIps: specifyNames(x.ExemptIps),
+        })
+    }
+
+    return &threatExceptions{Entries: ans}
+}
+
+type names struct {
+    Entries []name `xml:"entry"`
+}
+
+func (o *names) normalize() []string {
+    if o == nil {
+        return nil
+    }
+
+    ans := make([]string, 0, len(o.Entries))
+    for _, x := range o.Entries {
+        ans = append(ans, x.Name)
+    }
+
+    return ans
+}
+
+type name struct {
+    Name string `xml:"name,attr"`
+}
+
+func specifyNames(list []string) *names {
+    if len(list) == 0 {
+        return nil
+    }
+
+    ans := make([]name, 0, len(list))
+    for _, x := range list {
+        ans = append(ans, name{Name: x})
+    }
+
+    return &names{Entries: ans}
+}
+
+type sinkhole struct {
+    Ipv4Address string `xml:"ipv4-address,omitempty"`
+    Ipv6Address string `xml:"ipv6-address,omitempty"`
+}
+
+func specifySinkhole(e Entry) *sinkhole {
+    if e.SinkholeIpv4Address == "" && e.SinkholeIpv6Address == "" {
+        return nil
+    }
+
+    return &sinkhole{
+        Ipv4Address: e.SinkholeIpv4Address,
+        Ipv6Address: e.SinkholeIpv6Address,
+    }
+}
+
+type botnet_v1 struct {
+    Lists *botnetLists_v1 `xml:"lists"`
+    ThreatExceptions *names `xml:"threat-exception"`
+    Sinkhole *sinkhole `xml:"sinkhole"`
+}
+
+type botnetLists_v1 struct {
+    Entries []botnetList_v1 `xml:"entry"`
+}
+
+type botnetList_v1 struct {
+    Name string `xml:"name,attr"`
+    Action *action `xml:"action"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        Rules: specifyRules(e.Rules),
+        ThreatExceptions: specifyThreatExceptions(e.ThreatExceptions),
+    }
+
+    b := botnet_v1{
+        ThreatExceptions: specifyNames(e.DnsThreatExceptions),
+        Sinkhole: specifySinkhole(e),
+    }
+    if len(e.BotnetLists) > 0 {
+        list := make([]botnetList_v1, 0, len(e.BotnetLists))
+        for _, x := range e.BotnetLists {
+            list = append(list, botnetList_v1{
+                Name: x.Name,
+                Action: specifyAction(x.Action, "", 0),
+            })
+        }
+        b.Lists = &botnetLists_v1{Entries: list}
+    }
+    if b.Lists != nil || b.ThreatExceptions != nil || b.Sinkhole != nil {
+        ans.Botnet = &b
+    }
+
+    return ans
+}
+
+// PAN-OS 8.0+: botnet lists have a packet capture setting.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        Rules: o.Answer.Rules.normalize(),
+        ThreatExceptions: o.Answer.ThreatExceptions.normalize(),
+    }
+
+    if o.Answer.Botnet != nil {
+        if o.Answer.Botnet.Lists != nil {
+            ans.BotnetLists = make([]BotnetList, 0, len(o.Answer.Botnet.Lists.Entries))
+            for _, x := range o.Answer.Botnet.Lists.Entries {
+                act, _, _ := x.Action.normalize()
+                ans.BotnetLists = append(ans.BotnetLists, BotnetList{
+                    Name: x.Name,
+                    Action: act,
+                    PacketCapture: x.PacketCapture,
+                })
+            }
+        }
+        ans.DnsThreatExceptions = o.Answer.Botnet.ThreatExceptions.normalize()
+        if o.Answer.Botnet.Sinkhole != nil {
+            ans.SinkholeIpv4Address = o.Answer.Botnet.Sinkhole.Ipv4Address
+            ans.SinkholeIpv6Address = o.Answer.Botnet.Sinkhole.Ipv6Address
+        }
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Rules *rules `xml:"rules"`
+    ThreatExceptions *threatExceptions `xml:"threat-exception"`
+    Botnet *botnet_v2 `xml:"botnet-domains"`
+}
+
+type botnet_v2 struct {
+    Lists *botnetLists_v2 `xml:"lists"`
+    ThreatExceptions *names `xml:"threat-exception"`
+    Sinkhole *sinkhole `xml:"sinkhole"`
+}
+
+type botnetLists_v2 struct {
+    Entries []botnetList_v2 `xml:"entry"`
+}
+
+type botnetList_v2 struct {
+    Name string `xml:"name,attr"`
+    Action *action `xml:"action"`
+    PacketCapture string `xml:"packet-capture,omitempty"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        Description: e.Description,
+        Rules: specifyRules(e.Rules),
+        ThreatExceptions: specifyThreatExceptions(e.ThreatExceptions),
+    }
+
+    b := botnet_v2{
+        ThreatExceptions: specifyNames(e.DnsThreatExceptions),
+        Sinkhole: specifySinkhole(e),
+    }
+    if len(e.BotnetLists) > 0 {
+        list := make([]botnetList_v2, 0, len(e.BotnetLists))
+        for _, x := range e.BotnetLists {
+            list = append(list, botnetList_v2{
+                Name: x.Name,
+                Action: specifyAction(x.Action, "", 0),
+                PacketCapture: x.PacketCapture,
+            })
+        }
+        b.Lists = &botnetLists_v2{Entries: list}
+    }
+    if b.Lists != nil || b.ThreatExceptions != nil || b.Sinkhole != nil {
+        ans.Botnet = &b
+    }
+
+    return ans
+}
@@ -0,0 +1,87 @@
+package spyware
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+        }},
+        {"v1 with rules and exceptions", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    ThreatName: "any",
+                    Category: "any",
+                    Severities: []string{"critical", "high"},
+                    PacketCapture: PacketCaptureSinglePacket,
+                    Action: ActionResetBoth,
+                },
+                Rule{
+                    Name: "r2",
+                    ThreatName: "any",
+                    Category: "botnet",
+                    Severities: []string{"medium"},
+                    PacketCapture: PacketCaptureDisable,
+                    Action: ActionBlockIp,
+                    BlockIpTrackBy: TrackBySource,
+                    BlockIpDuration: 300,
+                },
+            },
+            ThreatExceptions: []ThreatException{
+                ThreatException{
+                    Name: "12345",
+                    PacketCapture: PacketCaptureDisable,
+                    Action: ActionAllow,
+                    ExemptIps: []string{"10.1.1.1", "10.1.1.2"},
+                },
+            },
+        }},
+        {"v1 with botnet", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t3",
+            BotnetLists: []BotnetList{
+                BotnetList{
+                    Name: "default-paloalto-dns",
+                    Action: ListActionSinkhole,
+                },
+            },
+            DnsThreatExceptions: []string{"4000001"},
+            SinkholeIpv4Address: "pan-sinkhole-default-ip",
+            SinkholeIpv6Address: "::1",
+        }},
+        {"v2 basic", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t4",
+            Description: "my description",
+        }},
+        {"v2 with botnet", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t5",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    ThreatName: "any",
+                    Category: "any",
+                    Severities: []string{"any"},
+                    Action: ActionDefault,
+                },
+            },
+            BotnetLists: []BotnetList{
+                BotnetList{
+                    Name: "default-paloalto-dns",
+                    Action: ListActionBlock,
+                    PacketCapture: PacketCaptureExtendedCapture,
+                },
+            },
+            SinkholeIpv4Address: "10.2.2.2",
+        }},
+    }
+}
@@ -0,0 +1,22 @@
+package urlfilter
+
+// Valid values for BlockListAction.
+const (
+    BlockListActionAlert = "alert"
+    BlockListActionBlock = "block"
+    BlockListActionContinue = "continue"
+    BlockListActionOverride = "override"
+)
+
+// Valid values for UcdMode.
+const (
+    UcdModeDisabled = "disabled"
+    UcdModeIpUser = "ip-user"
+    UcdModeDomainCredentials = "domain-credentials"
+    UcdModeGroupMapping = "group-mapping"
+)
+
+const (
+    singular = "url filtering profile"
+    plural = "url filtering profiles"
+)
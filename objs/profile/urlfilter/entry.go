@@ -0,0 +1,339 @@
+package urlfilter
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a URL
+// filtering security profile.
+//
+// DynamicUrl, BlockListAction, BlockList, and AllowList were removed in
+// PAN-OS 9.0 in favor of custom URL categories.
+//
+// The user credential detection (Ucd) fields require PAN-OS 8.0+.
+// UcdGroupMapping is only used if UcdMode is UcdModeGroupMapping.
+type Entry struct {
+    Name string
+    Description string
+    DynamicUrl bool
+    BlockListAction string
+    BlockList []string
+    AllowList []string
+    AllowCategories []string
+    AlertCategories []string
+    BlockCategories []string
+    ContinueCategories []string
+    OverrideCategories []string
+    TrackContainerPage bool
+    LogContainerPageOnly bool
+    SafeSearchEnforcement bool
+    LogHttpHeaderXff bool
+    LogHttpHeaderUserAgent bool
+    LogHttpHeaderReferer bool
+    UcdMode string
+    UcdGroupMapping string
+    UcdLogSeverity string
+    UcdAllowCategories []string
+    UcdAlertCategories []string
+    UcdBlockCategories []string
+    UcdContinueCategories []string
+
+    raw map[string] string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.DynamicUrl = s.DynamicUrl
+    o.BlockListAction = s.BlockListAction
+    o.BlockList = s.BlockList
+    o.AllowList = s.AllowList
+    o.AllowCategories = s.AllowCategories
+    o.AlertCategories = s.AlertCategories
+    o.BlockCategories = s.BlockCategories
+    o.ContinueCategories = s.ContinueCategories
+    o.OverrideCategories = s.OverrideCategories
+    o.TrackContainerPage = s.TrackContainerPage
+    o.LogContainerPageOnly = s.LogContainerPageOnly
+    o.SafeSearchEnforcement = s.SafeSearchEnforcement
+    o.LogHttpHeaderXff = s.LogHttpHeaderXff
+    o.LogHttpHeaderUserAgent = s.LogHttpHeaderUserAgent
+    o.LogHttpHeaderReferer = s.LogHttpHeaderReferer
+    o.UcdMode = s.UcdMode
+    o.UcdGroupMapping = s.UcdGroupMapping
+    o.UcdLogSeverity = s.UcdLogSeverity
+    o.UcdAllowCategories = s.UcdAllowCategories
+    o.UcdAlertCategories = s.UcdAlertCategories
+    o.UcdBlockCategories = s.UcdBlockCategories
+    o.UcdContinueCategories = s.UcdContinueCategories
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+// base is the part of the URL filtering profile common to all versions.
+type base struct {
+    AllowCategories *util.MemberType `xml:"allow"`
+    AlertCategories *util.MemberType `xml:"alert"`
+    BlockCategories *util.MemberType `xml:"block"`
+    ContinueCategories *util.MemberType `xml:"continue"`
+    OverrideCategories *util.MemberType `xml:"override"`
+    TrackContainerPage string `xml:"enable-container-page"`
+    LogContainerPageOnly string `xml:"log-container-page-only"`
+    SafeSearchEnforcement string `xml:"safe-search-enforcement"`
+    LogHttpHeaderXff string `xml:"log-http-hdr-xff"`
+    LogHttpHeaderUserAgent string `xml:"log-http-hdr-user-agent"`
+    LogHttpHeaderReferer string `xml:"log-http-hdr-referer"`
+}
+
+func (o *base) normalize(e *Entry) {
+    e.AllowCategories = util.MemToStr(o.AllowCategories)
+    e.AlertCategories = util.MemToStr(o.AlertCategories)
+    e.BlockCategories = util.MemToStr(o.BlockCategories)
+    e.ContinueCategories = util.MemToStr(o.ContinueCategories)
+    e.OverrideCategories = util.MemToStr(o.OverrideCategories)
+    e.TrackContainerPage = util.AsBool(o.TrackContainerPage)
+    e.LogContainerPageOnly = util.AsBool(o.LogContainerPageOnly)
+    e.SafeSearchEnforcement = util.AsBool(o.SafeSearchEnforcement)
+    e.LogHttpHeaderXff = util.AsBool(o.LogHttpHeaderXff)
+    e.LogHttpHeaderUserAgent = util.AsBool(o.LogHttpHeaderUserAgent)
+    e.LogHttpHeaderReferer = util.AsBool(o.LogHttpHeaderReferer)
+}
+
+func specifyBase(e Entry) base {
+    return base{
+        AllowCategories: util.StrToMem(e.AllowCategories),
+        AlertCategories: util.StrToMem(e.AlertCategories),
+        BlockCategories: util.StrToMem(e.BlockCategories),
+        ContinueCategories: util.StrToMem(e.ContinueCategories),
+        OverrideCategories: util.StrToMem(e.OverrideCategories),
+        TrackContainerPage: util.YesNo(e.TrackContainerPage),
+        LogContainerPageOnly: util.YesNo(e.LogContainerPageOnly),
+        SafeSearchEnforcement: util.YesNo(e.SafeSearchEnforcement),
+        LogHttpHeaderXff: util.YesNo(e.LogHttpHeaderXff),
+        LogHttpHeaderUserAgent: util.YesNo(e.LogHttpHeaderUserAgent),
+        LogHttpHeaderReferer: util.YesNo(e.LogHttpHeaderReferer),
+    }
+}
+
+// lists are the block and allow lists, removed in PAN-OS 9.0.
+type lists struct {
+    DynamicUrl string `xml:"dynamic-url"`
+    BlockListAction string `xml:"action,omitempty"`
+    BlockList *util.MemberType `xml:"block-list"`
+    AllowList *util.MemberType `xml:"allow-list"`
+}
+
+func (o *lists) normalize(e *Entry) {
+    e.DynamicUrl = util.AsBool(o.DynamicUrl)
+    e.BlockListAction = o.BlockListAction
+    e.BlockList = util.MemToStr(o.BlockList)
+    e.AllowList = util.MemToStr(o.AllowList)
+}
+
+func specifyLists(e Entry) lists {
+    return lists{
+        DynamicUrl: util.YesNo(e.DynamicUrl),
+        BlockListAction: e.BlockListAction,
+        BlockList: util.StrToMem(e.BlockList),
+        AllowList: util.StrToMem(e.AllowList),
+    }
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+    }
+
+    o.Answer.lists.normalize(&ans)
+    o.Answer.base.normalize(&ans)
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    lists
+    base
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        lists: specifyLists(e),
+        base: specifyBase(e),
+    }
+
+    return ans
+}
+
+// PAN-OS 8.0+: user credential detection added.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+    }
+
+    o.Answer.lists.normalize(&ans)
+    o.Answer.base.normalize(&ans)
+    o.Answer.Ucd.normalize(&ans)
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    lists
+    base
+    Ucd *ucd `xml:"credential-enforcement"`
+}
+
+type ucd struct {
+    Mode *ucdMode `xml:"mode"`
+    LogSeverity string `xml:"log-severity,omitempty"`
+    AllowCategories *util.MemberType `xml:"allow"`
+    AlertCategories *util.MemberType `xml:"alert"`
+    BlockCategories *util.MemberType `xml:"block"`
+    ContinueCategories *util.MemberType `xml:"continue"`
+}
+
+type ucdMode struct {
+    Disabled *string `xml:"disabled"`
+    IpUser *string `xml:"ip-user"`
+    DomainCredentials *string `xml:"domain-credentials"`
+    GroupMapping string `xml:"group-mapping,omitempty"`
+}
+
+func (o *ucd) normalize(e *Entry) {
+    if o == nil {
+        return
+    }
+
+    if o.Mode != nil {
+        switch {
+        case o.Mode.Disabled != nil:
+            e.UcdMode = UcdModeDisabled
+        case o.Mode.IpUser != nil:
+            e.UcdMode = UcdModeIpUser
+        case o.Mode.DomainCredentials != nil:
+            e.UcdMode = UcdModeDomainCredentials
+        case o.Mode.GroupMapping != "":
+            e.UcdMode = UcdModeGroupMapping
+            e.UcdGroupMapping = o.Mode.GroupMapping
+        }
+    }
+    e.UcdLogSeverity = o.LogSeverity
+    e.UcdAllowCategories = util.MemToStr(o.AllowCategories)
+    e.UcdAlertCategories = util.MemToStr(o.AlertCategories)
+    e.UcdBlockCategories = util.MemToStr(o.BlockCategories)
+    e.UcdContinueCategories = util.MemToStr(o.ContinueCategories)
+}
+
+func specifyUcd(e Entry) *ucd {
+    if e.UcdMode == "" && e.UcdLogSeverity == "" && len(e.UcdAllowCategories) == 0 && len(e.UcdAlertCategories) == 0 && len(e.UcdBlockCategories) == 0 && len(e.UcdContinueCategories) == 0 {
+        return nil
+    }
+
+    ans := &ucd{
+        LogSeverity: e.UcdLogSeverity,
+        AllowCategories: util.StrToMem(e.UcdAllowCategories),
+        AlertCategories: util.StrToMem(e.UcdAlertCategories),
+        BlockCategories: util.StrToMem(e.UcdBlockCategories),
+        ContinueCategories: util.StrToMem(e.UcdContinueCategories),
+    }
+
+    s := ""
+    switch e.UcdMode {
+    case UcdModeDisabled:
+        ans.Mode = &ucdMode{Disabled: &s}
+    case UcdModeIpUser:
+        ans.Mode = &ucdMode{IpUser: &s}
+    case UcdModeDomainCredentials:
+        ans.Mode = &ucdMode{DomainCredentials: &s}
+    case UcdModeGroupMapping:
+        ans.Mode = &ucdMode{GroupMapping: e.UcdGroupMapping}
+    }
+
+    return ans
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        Description: e.Description,
+        lists: specifyLists(e),
+        base: specifyBase(e),
+        Ucd: specifyUcd(e),
+    }
+
+    return ans
+}
+
+// PAN-OS 9.0+: block / allow lists and dynamic url removed, http header
+// insertion added.
+type container_v3 struct {
+    Answer entry_v3 `xml:"result>entry"`
+}
+
+func (o *container_v3) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+    }
+
+    o.Answer.base.normalize(&ans)
+    o.Answer.Ucd.normalize(&ans)
+
+    if o.Answer.Hhi != nil {
+        ans.raw = map[string] string{
+            "hhi": util.CleanRawXml(o.Answer.Hhi.Text),
+        }
+    }
+
+    return ans
+}
+
+type entry_v3 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    base
+    Ucd *ucd `xml:"credential-enforcement"`
+    Hhi *util.RawXml `xml:"http-header-insertion"`
+}
+
+func specify_v3(e Entry) interface{} {
+    ans := entry_v3{
+        Name: e.Name,
+        Description: e.Description,
+        base: specifyBase(e),
+        Ucd: specifyUcd(e),
+    }
+
+    if text, present := e.raw["hhi"]; present {
+        ans.Hhi = &util.RawXml{text}
+    }
+
+    return ans
+}
@@ -0,0 +1,76 @@
+package urlfilter
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+            TrackContainerPage: true,
+            LogContainerPageOnly: true,
+        }},
+        {"v1 with lists", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            DynamicUrl: true,
+            BlockListAction: BlockListActionBlock,
+            BlockList: []string{"*.example.com", "bad.example.org"},
+            AllowList: []string{"good.example.org"},
+            AllowCategories: []string{"news"},
+            AlertCategories: []string{"games", "shopping"},
+            BlockCategories: []string{"malware"},
+            ContinueCategories: []string{"gambling"},
+            OverrideCategories: []string{"hacking"},
+            SafeSearchEnforcement: true,
+            LogHttpHeaderXff: true,
+            LogHttpHeaderUserAgent: true,
+            LogHttpHeaderReferer: true,
+        }},
+        {"v2 basic", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t3",
+            BlockListAction: BlockListActionAlert,
+            BlockList: []string{"bad.example.org"},
+            BlockCategories: []string{"malware", "phishing"},
+        }},
+        {"v2 ucd disabled", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t4",
+            UcdMode: UcdModeDisabled,
+            UcdLogSeverity: "medium",
+            UcdAllowCategories: []string{"news"},
+            UcdAlertCategories: []string{"games"},
+            UcdBlockCategories: []string{"malware"},
+            UcdContinueCategories: []string{"shopping"},
+        }},
+        {"v2 ucd group mapping", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t5",
+            UcdMode: UcdModeGroupMapping,
+            UcdGroupMapping: "gm1",
+        }},
+        {"v3 basic", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t6",
+            Description: "my description",
+            AlertCategories: []string{"games"},
+            BlockCategories: []string{"malware"},
+        }},
+        {"v3 ucd ip user", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t7",
+            UcdMode: UcdModeIpUser,
+            UcdBlockCategories: []string{"any"},
+        }},
+        {"v3 with raw", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t8",
+            UcdMode: UcdModeDomainCredentials,
+            raw: map[string] string{
+                "hhi": "header insertion",
+            },
+        }},
+    }
+}
@@ -0,0 +1,18 @@
+package virus
+
+// Valid values for Decoder.Action, Decoder.WildfireAction, and
+// ApplicationException.Action.
+const (
+    ActionDefault = "default"
+    ActionAllow = "allow"
+    ActionAlert = "alert"
+    ActionDrop = "drop"
+    ActionResetClient = "reset-client"
+    ActionResetServer = "reset-server"
+    ActionResetBoth = "reset-both"
+)
+
+const (
+    singular = "antivirus profile"
+    plural = "antivirus profiles"
+)
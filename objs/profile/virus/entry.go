@@ -0,0 +1,170 @@
+package virus
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of an
+// antivirus security profile.
+//
+// ThreatExceptions is a list of threat IDs that are excluded from this profile.
+type Entry struct {
+    Name string
+    Description string
+    PacketCapture bool
+    Decoders []Decoder
+    ApplicationExceptions []ApplicationException
+    ThreatExceptions []string
+}
+
+// Decoder is the per-protocol action for signature and WildFire matches.
+type Decoder struct {
+    Name string
+    Action string
+    WildfireAction string
+}
+
+// ApplicationException overrides the decoder action for an application.
+type ApplicationException struct {
+    Application string
+    Action string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.PacketCapture = s.PacketCapture
+    o.Decoders = s.Decoders
+    o.ApplicationExceptions = s.ApplicationExceptions
+    o.ThreatExceptions = s.ThreatExceptions
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        PacketCapture: util.AsBool(o.Answer.PacketCapture),
+    }
+
+    if o.Answer.Decoders != nil {
+        ans.Decoders = make([]Decoder, 0, len(o.Answer.Decoders.Entries))
+        for _, d := range o.Answer.Decoders.Entries {
+            ans.Decoders = append(ans.Decoders, Decoder{
+                Name: d.Name,
+                Action: d.Action,
+                WildfireAction: d.WildfireAction,
+            })
+        }
+    }
+
+    if o.Answer.ApplicationExceptions != nil {
+        ans.ApplicationExceptions = make([]ApplicationException, 0, len(o.Answer.ApplicationExceptions.Entries))
+        for _, a := range o.Answer.ApplicationExceptions.Entries {
+            ans.ApplicationExceptions = append(ans.ApplicationExceptions, ApplicationException{
+                Application: a.Application,
+                Action: a.Action,
+            })
+        }
+    }
+
+    if o.Answer.ThreatExceptions != nil {
+        ans.ThreatExceptions = make([]string, 0, len(o.Answer.ThreatExceptions.Entries))
+        for _, t := range o.Answer.ThreatExceptions.Entries {
+            ans.ThreatExceptions = append(ans.ThreatExceptions, t.Name)
+        }
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    PacketCapture string `xml:"packet-capture"`
+    Decoders *decoders `xml:"decoder"`
+    ApplicationExceptions *appExceptions `xml:"application"`
+    ThreatExceptions *threatExceptions `xml:"threat-exception"`
+}
+
+type decoders struct {
+    Entries []decoder `xml:"entry"`
+}
+
+type decoder struct {
+    Name string `xml:"name,attr"`
+    Action string `xml:"action,omitempty"`
+    WildfireAction string `xml:"wildfire-action,omitempty"`
+}
+
+type appExceptions struct {
+    Entries []appException `xml:"entry"`
+}
+
+type appException struct {
+    Application string `xml:"name,attr"`
+    Action string `xml:"action,omitempty"`
+}
+
+type threatExceptions struct {
+    Entries []threatException `xml:"entry"`
+}
+
+type threatException struct {
+    Name string `xml:"name,attr"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        PacketCapture: util.YesNo(e.PacketCapture),
+    }
+
+    if len(e.Decoders) > 0 {
+        list := make([]decoder, 0, len(e.Decoders))
+        for _, d := range e.Decoders {
+            list = append(list, decoder{
+                Name: d.Name,
+                Action: d.Action,
+                WildfireAction: d.WildfireAction,
+            })
+        }
+        ans.Decoders = &decoders{Entries: list}
+    }
+
+    if len(e.ApplicationExceptions) > 0 {
+        list := make([]appException, 0, len(e.ApplicationExceptions))
+        for _, a := range e.ApplicationExceptions {
+            list = append(list, appException{
+                Application: a.Application,
+                Action: a.Action,
+            })
+        }
+        ans.ApplicationExceptions = &appExceptions{Entries: list}
+    }
+
+    if len(e.ThreatExceptions) > 0 {
+        list := make([]threatException, 0, len(e.ThreatExceptions))
+        for _, t := range e.ThreatExceptions {
+            list = append(list, threatException{Name: t})
+        }
+        ans.ThreatExceptions = &threatExceptions{Entries: list}
+    }
+
+    return ans
+}
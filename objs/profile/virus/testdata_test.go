@@ -0,0 +1,46 @@
+package virus
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+            PacketCapture: true,
+        }},
+        {"v1 with decoders", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Decoders: []Decoder{
+                Decoder{
+                    Name: "http",
+                    Action: ActionResetBoth,
+                    WildfireAction: ActionResetBoth,
+                },
+                Decoder{
+                    Name: "smtp",
+                    Action: ActionAlert,
+                    WildfireAction: ActionDefault,
+                },
+            },
+        }},
+        {"v1 with exceptions", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t3",
+            ApplicationExceptions: []ApplicationException{
+                ApplicationException{
+                    Application: "gmail-base",
+                    Action: ActionAlert,
+                },
+            },
+            ThreatExceptions: []string{"1234", "5678"},
+        }},
+    }
+}
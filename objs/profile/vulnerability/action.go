@@ -0,0 +1,71 @@
+package vulnerability
+
+// action is the element form of a threat action, where the selected action is
+// the name of the single child element.
+type action struct {
+    Default *string `xml:"default"`
+    Allow *string `xml:"allow"`
+    Alert *string `xml:"alert"`
+    Drop *string `xml:"drop"`
+    ResetClient *string `xml:"reset-client"`
+    ResetServer *string `xml:"reset-server"`
+    ResetBoth *string `xml:"reset-both"`
+    BlockIp *blockIp `xml:"block-ip"`
+}
+
+type blockIp struct {
+    TrackBy string `xml:"track-by,omitempty"`
+    Duration int `xml:"duration,omitempty"`
+}
+
+func (o *action) normalize() (string, string, int) {
+    if o == nil {
+        return "", "", 0
+    }
+
+    switch {
+    case o.Default != nil:
+        return ActionDefault, "", 0
+    case o.Allow != nil:
+        return ActionAllow, "", 0
+    case o.Alert != nil:
+        return ActionAlert, "", 0
+    case o.Drop != nil:
+        return ActionDrop, "", 0
+    case o.ResetClient != nil:
+        return ActionResetClient, "", 0
+    case o.ResetServer != nil:
+        return ActionResetServer, "", 0
+    case o.ResetBoth != nil:
+        return ActionResetBoth, "", 0
+    case o.BlockIp != nil:
+        return ActionBlockIp, o.BlockIp.TrackBy, o.BlockIp.Duration
+    }
+
+    return "", "", 0
+}
+
+func specifyAction(a, trackBy string, duration int) *action {
+    s := ""
+
+    switch a {
+    case ActionDefault:
+        return &action{Default: &s}
+    case ActionAllow:
+        return &action{Allow: &s}
+    case ActionAlert:
+        return &action{Alert: &s}
+    case ActionDrop:
+        return &action{Drop: &s}
+    case ActionResetClient:
+        return &action{ResetClient: &s}
+    case ActionResetServer:
+        return &action{ResetServer: &s}
+    case ActionResetBoth:
+        return &action{ResetBoth: &s}
+    case ActionBlockIp:
+        return &action{BlockIp: &blockIp{TrackBy: trackBy, Duration: duration}}
+    }
+
+    return nil
+}
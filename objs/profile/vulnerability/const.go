@@ -0,0 +1,38 @@
+package vulnerability
+
+// Valid values for Rule.Action and ThreatException.Action.
+const (
+    ActionDefault = "default"
+    ActionAllow = "allow"
+    ActionAlert = "alert"
+    ActionDrop = "drop"
+    ActionResetClient = "reset-client"
+    ActionResetServer = "reset-server"
+    ActionResetBoth = "reset-both"
+    ActionBlockIp = "block-ip"
+)
+
+// Valid values for Rule.Host.
+const (
+    HostAny = "any"
+    HostClient = "client"
+    HostServer = "server"
+)
+
+// Valid values for BlockIpTrackBy.
+const (
+    TrackBySource = "source"
+    TrackBySourceAndDestination = "source-and-destination"
+)
+
+// Valid values for PacketCapture.
+const (
+    PacketCaptureDisable = "disable"
+    PacketCaptureSinglePacket = "single-packet"
+    PacketCaptureExtendedCapture = "extended-capture"
+)
+
+const (
+    singular = "vulnerability profile"
+    plural = "vulnerability profiles"
+)
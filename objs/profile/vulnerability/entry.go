@@ -0,0 +1,312 @@
+package vulnerability
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a
+// vulnerability protection security profile.
+type Entry struct {
+    Name string
+    Description string
+    Rules []Rule
+    ThreatExceptions []ThreatException
+}
+
+// Rule is a single vulnerability protection rule.
+//
+// BlockIpTrackBy and BlockIpDuration are only used if Action is
+// ActionBlockIp.  Category requires PAN-OS 8.0+.
+type Rule struct {
+    Name string
+    ThreatName string
+    Cves []string
+    Host string
+    VendorIds []string
+    Severities []string
+    Category string
+    PacketCapture string
+    Action string
+    BlockIpTrackBy string
+    BlockIpDuration int
+}
+
+// ThreatException overrides the action for the given threat ID.
+type ThreatException struct {
+    Name string
+    PacketCapture string
+    Action string
+    BlockIpTrackBy string
+    BlockIpDuration int
+    ExemptIps []string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.Rules = s.Rules
+    o.ThreatExceptions = s.ThreatExceptions
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        ThreatExceptions: o.Answer.ThreatExceptions.normalize(),
+    }
+
+    if o.Answer.Rules != nil {
+        ans.Rules = make([]Rule, 0, len(o.Answer.Rules.Entries))
+        for _, x := range o.Answer.Rules.Entries {
+            r := Rule{
+                Name: x.Name,
+                ThreatName: x.ThreatName,
+                Cves: util.MemToStr(x.Cves),
+                Host: x.Host,
+                VendorIds: util.MemToStr(x.VendorIds),
+                Severities: util.MemToStr(x.Severities),
+                PacketCapture: x.PacketCapture,
+            }
+            r.Action, r.BlockIpTrackBy, r.BlockIpDuration = x.Action.normalize()
+            ans.Rules = append(ans.Rules, r)
+        }
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Rules *rules_v1 `xml:"rules"`
+    ThreatExceptions *threatExceptions `xml:"threat-exception"`
+}
+
+type rules_v1 struct {
+    Entries []rule_v1 `xml:"entry"`
+}
+
+type rule_v1 struct {
+    Name string `xml:"name,attr"`
+    ThreatName string `xml:"threat-name,omitempty"`
+    Cves *util.MemberType `xml:"cve"`
+    Host string `xml:"host,omitempty"`
+    VendorIds *util.MemberType `xml:"vendor-id"`
+    Severities *util.MemberType `xml:"severity"`
+    PacketCapture string `xml:"packet-capture,omitempty"`
+    Action *action `xml:"action"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        ThreatExceptions: specifyThreatExceptions(e.ThreatExceptions),
+    }
+
+    if len(e.Rules) > 0 {
+        list := make([]rule_v1, 0, len(e.Rules))
+        for _, x := range e.Rules {
+            list = append(list, rule_v1{
+                Name: x.Name,
+                ThreatName: x.ThreatName,
+                Cves: util.StrToMem(x.Cves),
+                Host: x.Host,
+                VendorIds: util.StrToMem(x.VendorIds),
+                Severities: util.StrToMem(x.Severities),
+                PacketCapture: x.PacketCapture,
+                Action: specifyAction(x.Action, x.BlockIpTrackBy, x.BlockIpDuration),
+            })
+        }
+        ans.Rules = &rules_v1{Entries: list}
+    }
+
+    return ans
+}
+
+// PAN-OS 8.0+: rules have a category.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        ThreatExceptions: o.Answer.ThreatExceptions.normalize(),
+    }
+
+    if o.Answer.Rules != nil {
+        ans.Rules = make([]Rule, 0, len(o.Answer.Rules.Entries))
+        for _, x := range o.Answer.Rules.Entries {
+            r := Rule{
+                Name: x.Name,
+                ThreatName: x.ThreatName,
+                Cves: util.MemToStr(x.Cves),
+                Host: x.Host,
+                VendorIds: util.MemToStr(x.VendorIds),
+                Severities: util.MemToStr(x.Severities),
+                Category: x.Category,
+                PacketCapture: x.PacketCapture,
+            }
+            r.Action, r.BlockIpTrackBy, r.BlockIpDuration = x.Action.normalize()
+            ans.Rules = append(ans.Rules, r)
+        }
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Rules *rules_v2 `xml:"rules"`
+    ThreatExceptions *threatExceptions `xml:"threat-exception"`
+}
+
+type rules_v2 struct {
+    Entries []rule_v2 `xml:"entry"`
+}
+
+type rule_v2 struct {
+    Name string `xml:"name,attr"`
+    ThreatName string `xml:"threat-name,omitempty"`
+    Cves *util.MemberType `xml:"cve"`
+    Host string `xml:"host,omitempty"`
+    VendorIds *util.MemberType `xml:"vendor-id"`
+    Severities *util.MemberType `xml:"severity"`
+    Category string `xml:"category,omitempty"`
+    PacketCapture string `xml:"packet-capture,omitempty"`
+    Action *action `xml:"action"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        Description: e.Description,
+        ThreatExceptions: specifyThreatExceptions(e.ThreatExceptions),
+    }
+
+    if len(e.Rules) > 0 {
+        list := make([]rule_v2, 0, len(e.Rules))
+        for _, x := range e.Rules {
+            list = append(list, rule_v2{
+                Name: x.Name,
+                ThreatName: x.ThreatName,
+                Cves: util.StrToMem(x.Cves),
+                Host: x.Host,
+                VendorIds: util.StrToMem(x.VendorIds),
+                Severities: util.StrToMem(x.Severities),
+                Category: x.Category,
+                PacketCapture: x.PacketCapture,
+                Action: specifyAction(x.Action, x.BlockIpTrackBy, x.BlockIpDuration),
+            })
+        }
+        ans.Rules = &rules_v2{Entries: list}
+    }
+
+    return ans
+}
+
+type threatExceptions struct {
+    Entries []threatException `xml:"entry"`
+}
+
+func (o *threatExceptions) normalize() []ThreatException {
+    if o == nil {
+        return nil
+    }
+
+    ans := make([]ThreatException, 0, len(o.Entries))
+    for _, x := range o.Entries {
+        te := ThreatException{
+            Name: x.Name,
+            PacketCapture: x.PacketCapture,
+        }
+        te.Action, te.BlockIpTrackBy, te.BlockIpDuration = x.Action.normalize()
+        if x.ExemptIps != nil {
+            te.ExemptIps = make([]string, 0, len(x.ExemptIps.Entries))
+            for _, ip := range x.ExemptIps.Entries {
+                te.ExemptIps = append(te.ExemptIps, ip.Name)
+            }
+        }
+        ans = append(ans, te)
+    }
+
+    return ans
+}
+
+type threatException struct {
+    Name string `xml:"name,attr"`
+    PacketCapture string `xml:"packet-capture,omitempty"`
+    Action *action `xml:"action"`
+    ExemptIps *names `xml:"exempt-ip"`
+}
+
+func specifyThreatExceptions(list []ThreatException) *threatExceptions {
+    if len(list) == 0 {
+        return nil
+    }
+
+    ans := make([]threatException, 0, len(list))
+    for _, x := range list {
+        ans = append(ans, threatException{
+            Name: x.Name,
+            PacketCapture: x.PacketCapture,
+            Action: specifyAction(x.Action, x.BlockIpTrackBy, x.BlockIpDuration),
+            ExemptIps: specifyNames(x.ExemptIps),
+        })
+    }
+
+    return &threatExceptions{Entries: ans}
+}
+
+type names struct {
+    Entries []name `xml:"entry"`
+}
+
+func (o *names) normalize() []string {
+    if o == nil {
+        return nil
+    }
+
+    ans := make([]string, 0, len(o.Entries))
+    for _, x := range o.Entries {
+        ans = append(ans, x.Name)
+    }
+
+    return ans
+}
+
+type name struct {
+    Name string `xml:"name,attr"`
+}
+
+func specifyNames(list []string) *names {
+    if len(list) == 0 {
+        return nil
+    }
+
+    ans := make([]name, 0, len(list))
+    for _, x := range list {
+        ans = append(ans, name{Name: x})
+    }
+
+    return &names{Entries: ans}
+}
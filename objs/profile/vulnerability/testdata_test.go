@@ -0,0 +1,70 @@
+package vulnerability
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+        }},
+        {"v1 with rules and exceptions", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    ThreatName: "any",
+                    Cves: []string{"any"},
+                    Host: HostClient,
+                    VendorIds: []string{"any"},
+                    Severities: []string{"critical", "high"},
+                    PacketCapture: PacketCaptureSinglePacket,
+                    Action: ActionResetBoth,
+                },
+                Rule{
+                    Name: "r2",
+                    ThreatName: "any",
+                    Cves: []string{"CVE-2019-0001"},
+                    Host: HostAny,
+                    Severities: []string{"medium"},
+                    Action: ActionBlockIp,
+                    BlockIpTrackBy: TrackBySourceAndDestination,
+                    BlockIpDuration: 60,
+                },
+            },
+            ThreatExceptions: []ThreatException{
+                ThreatException{
+                    Name: "30001",
+                    Action: ActionAlert,
+                    ExemptIps: []string{"10.1.1.1"},
+                },
+            },
+        }},
+        {"v2 basic", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t3",
+            Description: "my description",
+        }},
+        {"v2 with category", version.Number{8, 0, 0, ""}, Entry{
+            Name: "t4",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    ThreatName: "any",
+                    Host: HostServer,
+                    Severities: []string{"any"},
+                    Category: "brute-force",
+                    PacketCapture: PacketCaptureDisable,
+                    Action: ActionDefault,
+                },
+            },
+        }},
+    }
+}
@@ -0,0 +1,19 @@
+package wildfire
+
+// Valid values for Rule.Direction.
+const (
+    DirectionUpload = "upload"
+    DirectionDownload = "download"
+    DirectionBoth = "both"
+)
+
+// Valid values for Rule.Analysis.
+const (
+    AnalysisPublicCloud = "public-cloud"
+    AnalysisPrivateCloud = "private-cloud"
+)
+
+const (
+    singular = "wildfire analysis profile"
+    plural = "wildfire analysis profiles"
+)
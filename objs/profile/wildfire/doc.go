@@ -0,0 +1,6 @@
+/*
+Package wildfire is the client.Objects.WildfireAnalysisProfile namespace.
+
+Normalized object:  Entry
+*/
+package wildfire
@@ -0,0 +1,39 @@
+package wildfire
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+        }},
+        {"v1 with rules", version.Number{7, 1, 0, ""}, Entry{
+            Name: "t2",
+            Rules: []Rule{
+                Rule{
+                    Name: "r1",
+                    Applications: []string{"any"},
+                    FileTypes: []string{"any"},
+                    Direction: DirectionBoth,
+                    Analysis: AnalysisPublicCloud,
+                },
+                Rule{
+                    Name: "r2",
+                    Applications: []string{"smtp"},
+                    FileTypes: []string{"pe", "pdf"},
+                    Direction: DirectionUpload,
+                    Analysis: AnalysisPrivateCloud,
+                },
+            },
+        }},
+    }
+}
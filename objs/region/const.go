@@ -0,0 +1,6 @@
+package region
+
+const (
+    singular = "region"
+    plural = "regions"
+)
@@ -0,0 +1,6 @@
+/*
+Package region is the client.Objects.Region namespace.
+
+Normalized object:  Entry
+*/
+package region
@@ -0,0 +1,81 @@
+package region
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a custom
+// region.
+//
+// Latitude and Longitude are the geographic coordinates of the region, and
+// are only sent if at least one of them is non-zero.  Addresses is a list of
+// IP addresses, IP ranges, or subnets that belong to this region.
+type Entry struct {
+    Name string
+    Latitude float64
+    Longitude float64
+    Addresses []string // unordered
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Latitude = s.Latitude
+    o.Longitude = s.Longitude
+    o.Addresses = s.Addresses
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Addresses: util.MemToStr(o.Answer.Addresses),
+    }
+
+    if o.Answer.Geo != nil {
+        ans.Latitude = o.Answer.Geo.Latitude
+        ans.Longitude = o.Answer.Geo.Longitude
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Geo *geo `xml:"geo-location"`
+    Addresses *util.MemberType `xml:"address"`
+}
+
+type geo struct {
+    Latitude float64 `xml:"latitude"`
+    Longitude float64 `xml:"longitude"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Addresses: util.StrToMem(e.Addresses),
+    }
+
+    if e.Latitude != 0 || e.Longitude != 0 {
+        ans.Geo = &geo{
+            Latitude: e.Latitude,
+            Longitude: e.Longitude,
+        }
+    }
+
+    return ans
+}
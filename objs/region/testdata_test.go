@@ -0,0 +1,26 @@
+package region
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 addresses only", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t1",
+            Addresses: []string{"10.1.1.0/24", "10.2.2.1-10.2.2.10"},
+        }},
+        {"v1 with geo location", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t2",
+            Latitude: 37.3861,
+            Longitude: -122.0839,
+            Addresses: []string{"192.168.1.1"},
+        }},
+    }
+}
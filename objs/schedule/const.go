@@ -0,0 +1,18 @@
+package schedule
+
+// Valid values for Type.
+const (
+    TypeRecurring = "recurring"
+    TypeNonRecurring = "non-recurring"
+)
+
+// Valid values for RecurringType.
+const (
+    RecurringDaily = "daily"
+    RecurringWeekly = "weekly"
+)
+
+const (
+    singular = "schedule"
+    plural = "schedules"
+)
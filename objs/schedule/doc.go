@@ -0,0 +1,6 @@
+/*
+Package schedule is the client.Objects.Schedule namespace.
+
+Normalized object:  Entry
+*/
+package schedule
@@ -0,0 +1,142 @@
+package schedule
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a schedule.
+//
+// For recurring schedules, times are formatted as "hh:mm-hh:mm", and
+// RecurringType selects if DailyTimes or the Weekly*Times are used.  For
+// non-recurring schedules, NonRecurringDateTimes are formatted as
+// "YYYY/MM/DD@hh:mm-YYYY/MM/DD@hh:mm".
+type Entry struct {
+    Name string
+    Type string
+    RecurringType string
+    DailyTimes []string // ordered
+    WeeklySundayTimes []string // ordered
+    WeeklyMondayTimes []string // ordered
+    WeeklyTuesdayTimes []string // ordered
+    WeeklyWednesdayTimes []string // ordered
+    WeeklyThursdayTimes []string // ordered
+    WeeklyFridayTimes []string // ordered
+    WeeklySaturdayTimes []string // ordered
+    NonRecurringDateTimes []string // ordered
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Type = s.Type
+    o.RecurringType = s.RecurringType
+    o.DailyTimes = s.DailyTimes
+    o.WeeklySundayTimes = s.WeeklySundayTimes
+    o.WeeklyMondayTimes = s.WeeklyMondayTimes
+    o.WeeklyTuesdayTimes = s.WeeklyTuesdayTimes
+    o.WeeklyWednesdayTimes = s.WeeklyWednesdayTimes
+    o.WeeklyThursdayTimes = s.WeeklyThursdayTimes
+    o.WeeklyFridayTimes = s.WeeklyFridayTimes
+    o.WeeklySaturdayTimes = s.WeeklySaturdayTimes
+    o.NonRecurringDateTimes = s.NonRecurringDateTimes
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+    }
+
+    switch {
+    case o.Answer.Type.Recurring != nil:
+        ans.Type = TypeRecurring
+        r := o.Answer.Type.Recurring
+        switch {
+        case r.Daily != nil:
+            ans.RecurringType = RecurringDaily
+            ans.DailyTimes = util.MemToStr(r.Daily)
+        case r.Weekly != nil:
+            ans.RecurringType = RecurringWeekly
+            ans.WeeklySundayTimes = util.MemToStr(r.Weekly.Sunday)
+            ans.WeeklyMondayTimes = util.MemToStr(r.Weekly.Monday)
+            ans.WeeklyTuesdayTimes = util.MemToStr(r.Weekly.Tuesday)
+            ans.WeeklyWednesdayTimes = util.MemToStr(r.Weekly.Wednesday)
+            ans.WeeklyThursdayTimes = util.MemToStr(r.Weekly.Thursday)
+            ans.WeeklyFridayTimes = util.MemToStr(r.Weekly.Friday)
+            ans.WeeklySaturdayTimes = util.MemToStr(r.Weekly.Saturday)
+        }
+    case o.Answer.Type.NonRecurring != nil:
+        ans.Type = TypeNonRecurring
+        ans.NonRecurringDateTimes = util.MemToStr(o.Answer.Type.NonRecurring)
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Type scheduleType `xml:"schedule-type"`
+}
+
+type scheduleType struct {
+    Recurring *recurring `xml:"recurring"`
+    NonRecurring *util.MemberType `xml:"non-recurring"`
+}
+
+type recurring struct {
+    Daily *util.MemberType `xml:"daily"`
+    Weekly *weekly `xml:"weekly"`
+}
+
+type weekly struct {
+    Sunday *util.MemberType `xml:"sunday"`
+    Monday *util.MemberType `xml:"monday"`
+    Tuesday *util.MemberType `xml:"tuesday"`
+    Wednesday *util.MemberType `xml:"wednesday"`
+    Thursday *util.MemberType `xml:"thursday"`
+    Friday *util.MemberType `xml:"friday"`
+    Saturday *util.MemberType `xml:"saturday"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+    }
+
+    switch e.Type {
+    case TypeRecurring:
+        r := &recurring{}
+        switch e.RecurringType {
+        case RecurringDaily:
+            r.Daily = util.StrToMem(e.DailyTimes)
+        case RecurringWeekly:
+            r.Weekly = &weekly{
+                Sunday: util.StrToMem(e.WeeklySundayTimes),
+                Monday: util.StrToMem(e.WeeklyMondayTimes),
+                Tuesday: util.StrToMem(e.WeeklyTuesdayTimes),
+                Wednesday: util.StrToMem(e.WeeklyWednesdayTimes),
+                Thursday: util.StrToMem(e.WeeklyThursdayTimes),
+                Friday: util.StrToMem(e.WeeklyFridayTimes),
+                Saturday: util.StrToMem(e.WeeklySaturdayTimes),
+            }
+        }
+        ans.Type.Recurring = r
+    case TypeNonRecurring:
+        ans.Type.NonRecurring = util.StrToMem(e.NonRecurringDateTimes)
+    }
+
+    return ans
+}
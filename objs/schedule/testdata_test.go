@@ -0,0 +1,35 @@
+package schedule
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 recurring daily", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t1",
+            Type: TypeRecurring,
+            RecurringType: RecurringDaily,
+            DailyTimes: []string{"08:00-12:00", "13:00-17:00"},
+        }},
+        {"v1 recurring weekly", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t2",
+            Type: TypeRecurring,
+            RecurringType: RecurringWeekly,
+            WeeklyMondayTimes: []string{"08:00-17:00"},
+            WeeklyWednesdayTimes: []string{"09:00-10:00", "14:00-15:00"},
+            WeeklySaturdayTimes: []string{"00:00-23:59"},
+        }},
+        {"v1 non-recurring", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t3",
+            Type: TypeNonRecurring,
+            NonRecurringDateTimes: []string{"2019/01/01@00:00-2019/01/31@23:59"},
+        }},
+    }
+}
@@ -0,0 +1,9 @@
+/*
+Package urlcat is the client.Objects.CustomUrlCategory namespace.
+
+Firewall categories default to vsys1 when no vsys is given; use "shared" for
+shared categories.
+
+Normalized object:  Entry
+*/
+package urlcat
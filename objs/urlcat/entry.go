@@ -0,0 +1,103 @@
+package urlcat
+
+import (
+    "encoding/xml"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Entry is a normalized, version independent representation of a custom
+// URL category.
+//
+// Sites is the list of URLs if Type is TypeUrlList, or the list of predefined
+// URL categories if Type is TypeCategoryMatch.
+//
+// Type requires PAN-OS 9.0+; prior to that, all custom URL categories are
+// URL lists.
+type Entry struct {
+    Name string
+    Description string
+    Sites []string
+    Type string
+}
+
+// Copy copies the information from source Entry `s` to this object.  As the
+// Name field relates to the XPATH of this object, this field is not copied.
+func (o *Entry) Copy(s Entry) {
+    o.Description = s.Description
+    o.Sites = s.Sites
+    o.Type = s.Type
+}
+
+/** Structs / functions for this namespace. **/
+
+type normalizer interface {
+    Normalize() Entry
+}
+
+type container_v1 struct {
+    Answer entry_v1 `xml:"result>entry"`
+}
+
+func (o *container_v1) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        Sites: util.MemToStr(o.Answer.Sites),
+    }
+
+    return ans
+}
+
+type entry_v1 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Sites *util.MemberType `xml:"list"`
+}
+
+func specify_v1(e Entry) interface{} {
+    ans := entry_v1{
+        Name: e.Name,
+        Description: e.Description,
+        Sites: util.StrToMem(e.Sites),
+    }
+
+    return ans
+}
+
+// PAN-OS 9.0+: category match type added.
+type container_v2 struct {
+    Answer entry_v2 `xml:"result>entry"`
+}
+
+func (o *container_v2) Normalize() Entry {
+    ans := Entry{
+        Name: o.Answer.Name,
+        Description: o.Answer.Description,
+        Sites: util.MemToStr(o.Answer.Sites),
+        Type: o.Answer.Type,
+    }
+
+    return ans
+}
+
+type entry_v2 struct {
+    XMLName xml.Name `xml:"entry"`
+    Name string `xml:"name,attr"`
+    Description string `xml:"description,omitempty"`
+    Sites *util.MemberType `xml:"list"`
+    Type string `xml:"type,omitempty"`
+}
+
+func specify_v2(e Entry) interface{} {
+    ans := entry_v2{
+        Name: e.Name,
+        Description: e.Description,
+        Sites: util.StrToMem(e.Sites),
+        Type: e.Type,
+    }
+
+    return ans
+}
@@ -0,0 +1,101 @@
+package urlcat
+
+import (
+    "testing"
+    "reflect"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestFwNormalization(t *testing.T) {
+    testCases := getTests()
+
+    mc := &testdata.MockClient{}
+    ns := &FwUrlCategory{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Version = tc.version
+            mc.Reset()
+            mc.AddResp("")
+            err := ns.Set("", tc.conf)
+            if err != nil {
+                t.Errorf("Error in set: %s", err)
+            } else {
+                mc.AddResp(mc.Elm)
+                r, err := ns.Get("", tc.conf.Name)
+                if err != nil {
+                    t.Errorf("Error in get: %s", err)
+                } else if !reflect.DeepEqual(tc.conf, r) {
+                    t.Errorf("%#v != %#v", tc.conf, r)
+                }
+            }
+        })
+    }
+}
+
+func TestFwSetSite(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &FwUrlCategory{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    if err := ns.SetSite("vsys1", "cat1", "example.com"); err != nil {
+        t.Fatalf("Error in set site: %s", err)
+    }
+    if mc.Function != "set" {
+        t.Errorf("Function is %q, not set", mc.Function)
+    }
+    if mc.Path != "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/profiles/custom-url-category/entry[@name='cat1']/list" {
+        t.Errorf("Path is wrong: %s", mc.Path)
+    }
+    if mc.Elm != "<member>example.com</member>" {
+        t.Errorf("Elm is wrong: %s", mc.Elm)
+    }
+}
+
+func TestFwDeleteSite(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &FwUrlCategory{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    if err := ns.DeleteSite("", Entry{Name: "cat1"}, "example.com"); err != nil {
+        t.Fatalf("Error in delete site: %s", err)
+    }
+    if mc.Function != "delete" {
+        t.Errorf("Function is %q, not delete", mc.Function)
+    }
+    if mc.Path != "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/profiles/custom-url-category/entry[@name='cat1']/list/member[text()='example.com']" {
+        t.Errorf("Path is wrong: %s", mc.Path)
+    }
+}
+
+func TestFwVsys(t *testing.T) {
+    testCases := []struct {
+        vsys string
+        path string
+    }{
+        {"", "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/profiles/custom-url-category/entry[@name='cat1']"},
+        {"vsys2", "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys2']/profiles/custom-url-category/entry[@name='cat1']"},
+        {"shared", "/config/shared/profiles/custom-url-category/entry[@name='cat1']"},
+    }
+
+    mc := &testdata.MockClient{}
+    ns := &FwUrlCategory{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    for _, tc := range testCases {
+        t.Run(tc.vsys, func(t *testing.T) {
+            if err := ns.Delete(tc.vsys, "cat1"); err != nil {
+                t.Fatalf("Error in delete: %s", err)
+            }
+            if mc.Path != tc.path {
+                t.Errorf("Path is wrong: %s", mc.Path)
+            }
+        })
+    }
+}
@@ -0,0 +1,74 @@
+package urlcat
+
+import (
+    "testing"
+    "reflect"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestPanoNormalization(t *testing.T) {
+    testCases := getTests()
+
+    mc := &testdata.MockClient{}
+    ns := &PanoUrlCategory{}
+    ns.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Version = tc.version
+            mc.Reset()
+            mc.AddResp("")
+            err := ns.Set("", tc.conf)
+            if err != nil {
+                t.Errorf("Error in set: %s", err)
+            } else {
+                mc.AddResp(mc.Elm)
+                r, err := ns.Get("", tc.conf.Name)
+                if err != nil {
+                    t.Errorf("Error in get: %s", err)
+                } else if !reflect.DeepEqual(tc.conf, r) {
+                    t.Errorf("%#v != %#v", tc.conf, r)
+                }
+            }
+        })
+    }
+}
+
+func TestPanoSetSite(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &PanoUrlCategory{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    if err := ns.SetSite("dg1", "cat1", "example.com"); err != nil {
+        t.Fatalf("Error in set site: %s", err)
+    }
+    if mc.Function != "set" {
+        t.Errorf("Function is %q, not set", mc.Function)
+    }
+    if mc.Path != "/config/devices/entry[@name='localhost.localdomain']/device-group/entry[@name='dg1']/profiles/custom-url-category/entry[@name='cat1']/list" {
+        t.Errorf("Path is wrong: %s", mc.Path)
+    }
+    if mc.Elm != "<member>example.com</member>" {
+        t.Errorf("Elm is wrong: %s", mc.Elm)
+    }
+}
+
+func TestPanoDeleteSite(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &PanoUrlCategory{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    if err := ns.DeleteSite("dg1", Entry{Name: "cat1"}, "example.com"); err != nil {
+        t.Fatalf("Error in delete site: %s", err)
+    }
+    if mc.Function != "delete" {
+        t.Errorf("Function is %q, not delete", mc.Function)
+    }
+    if mc.Path != "/config/devices/entry[@name='localhost.localdomain']/device-group/entry[@name='dg1']/profiles/custom-url-category/entry[@name='cat1']/list/member[text()='example.com']" {
+        t.Errorf("Path is wrong: %s", mc.Path)
+    }
+}
@@ -0,0 +1,35 @@
+package urlcat
+
+import (
+    "github.com/inwinstack/pango/version"
+)
+
+type tc struct {
+    desc string
+    version version.Number
+    conf Entry
+}
+
+func getTests() []tc {
+    return []tc{
+        {"v1 basic", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t1",
+            Description: "my description",
+        }},
+        {"v1 with sites", version.Number{8, 1, 0, ""}, Entry{
+            Name: "t2",
+            Sites: []string{"example.com", "*.example.org"},
+        }},
+        {"v2 url list", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t3",
+            Description: "my description",
+            Sites: []string{"example.com"},
+            Type: TypeUrlList,
+        }},
+        {"v2 category match", version.Number{9, 0, 0, ""}, Entry{
+            Name: "t4",
+            Sites: []string{"news", "shopping"},
+            Type: TypeCategoryMatch,
+        }},
+    }
+}
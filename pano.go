@@ -1,6 +1,7 @@
 package pango
 
 import (
+    "context"
     "encoding/xml"
 
     "github.com/inwinstack/pango/util"
@@ -13,6 +14,9 @@ import (
     "github.com/inwinstack/pango/pnrm"
     "github.com/inwinstack/pango/licen"
     "github.com/inwinstack/pango/userid"
+    "github.com/inwinstack/pango/snapshot"
+    "github.com/inwinstack/pango/jobs"
+    "github.com/inwinstack/pango/updates"
 )
 
 
@@ -23,6 +27,9 @@ import (
 // It has the following namespaces:
 //      * Licensing
 //      * UserId
+//      * Snapshots
+//      * Jobs
+//      * Updates
 type Panorama struct {
     Client
 
@@ -30,6 +37,9 @@ type Panorama struct {
     Device *dev.PanoDev
     Licensing *licen.Licen
     UserId *userid.UserId
+    Snapshots *snapshot.Snapshot
+    Jobs *jobs.Jobs
+    Updates *updates.Updates
     Panorama *pnrm.Pnrm
     Objects *objs.PanoObjs
     Policies *poli.PanoPoli
@@ -45,6 +55,8 @@ type Panorama struct {
 //  * Protocol: https
 //  * Port: (unspecified)
 //  * Timeout: 10
+//  * JobPollInterval: 1 second
+//  * JobPollMaxInterval: 10 seconds
 //  * Logging: LogAction | LogUid
 func (c *Panorama) Initialize() error {
     if len(c.rb) == 0 {
@@ -67,6 +79,27 @@ func (c *Panorama) Initialize() error {
     return nil
 }
 
+// WithContext returns a shallow copy of this Panorama whose API calls, including
+// those made through any of its namespaces, are bound to the given context.
+func (c *Panorama) WithContext(ctx context.Context) *Panorama {
+    ans := &Panorama{Client: *c.Client.WithContext(ctx)}
+    ans.initNamespaces()
+    ans.copySettings(c)
+
+    return ans
+}
+
+// WithBatch returns a shallow copy of this Panorama whose Set, Edit, Delete,
+// and Move calls, including those made through any of its namespaces, are
+// added to the given batch instead of being sent to PAN-OS.
+func (c *Panorama) WithBatch(b *Batch) *Panorama {
+    ans := &Panorama{Client: *c.Client.WithBatch(b)}
+    ans.initNamespaces()
+    ans.copySettings(c)
+
+    return ans
+}
+
 // CommitAll performs a Panorama commit-all.
 //
 // Param dg is the device group you want to commit-all on.  Note that all other
@@ -111,8 +144,31 @@ func (c *Panorama) CommitAll(dg, desc string, serials []string, tmpl, sync bool)
     return job, c.WaitForJob(job, nil)
 }
 
+// DiffDeviceGroup returns the uncommitted changes made to the given device
+// group.
+//
+// If dg is empty, then "shared" is used.
+func (c *Panorama) DiffDeviceGroup(dg string) (ConfigDiff, error) {
+    return c.DiffConfig(util.DeviceGroupXpathPrefix(dg))
+}
+
+// DiffTemplate returns the uncommitted changes made to the given template or
+// template stack.  Only one of tmpl or ts should be specified.
+func (c *Panorama) DiffTemplate(tmpl, ts string) (ConfigDiff, error) {
+    return c.DiffConfig(util.TemplateXpathPrefix(tmpl, ts))
+}
+
 /** Private functions **/
 
+// copySettings copies the user configurable namespace settings from `s`
+// to this Panorama's namespaces.
+func (c *Panorama) copySettings(s *Panorama) {
+    if s.Updates != nil {
+        c.Updates.RebootPollInterval = s.Updates.RebootPollInterval
+        c.Updates.RebootTimeout = s.Updates.RebootTimeout
+    }
+}
+
 func (c *Panorama) initNamespaces() {
     c.Device = &dev.PanoDev{}
     c.Device.Initialize(c)
@@ -123,6 +179,15 @@ func (c *Panorama) initNamespaces() {
     c.UserId = &userid.UserId{}
     c.UserId.Initialize(c)
 
+    c.Snapshots = &snapshot.Snapshot{}
+    c.Snapshots.Initialize(c)
+
+    c.Jobs = &jobs.Jobs{}
+    c.Jobs.Initialize(c)
+
+    c.Updates = &updates.Updates{}
+    c.Updates.Initialize(c)
+
     c.Panorama = &pnrm.Pnrm{}
     c.Panorama.Initialize(c)
 
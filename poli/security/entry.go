@@ -1,6 +1,7 @@
 package security
 
 import (
+    "fmt"
     "encoding/xml"
 
     "github.com/inwinstack/pango/util"
@@ -13,6 +14,12 @@ import (
 // the value is a list of specific vsys on that device.  The list of vsys is
 // nil if all vsys on that device should be included or if the device is a
 // virtual firewall (and thus only has vsys1).
+//
+// Group is the security profile group to attach to this rule, and is
+// mutually exclusive with the individual security profiles (Virus, Spyware,
+// Vulnerability, UrlFiltering, FileBlocking, WildFireAnalysis, and
+// DataFiltering).  Set and Edit return an error if both Group and any of the
+// individual profiles are set.
 type Entry struct {
     Name string
     Type string
@@ -151,6 +158,20 @@ func (o *Entry) Copy(s Entry) {
     o.DataFiltering = s.DataFiltering
 }
 
+// profileError returns an error if both a security profile group and
+// individual security profiles are specified.
+func (o *Entry) profileError() error {
+    if o.Group == "" {
+        return nil
+    }
+
+    if o.Virus != "" || o.Spyware != "" || o.Vulnerability != "" || o.UrlFiltering != "" || o.FileBlocking != "" || o.WildFireAnalysis != "" || o.DataFiltering != "" {
+        return fmt.Errorf("Security rule %q has both a profile group and individual profiles", o.Name)
+    }
+
+    return nil
+}
+
 /** Structs / functions for normalization. **/
 
 type normalizer interface {
@@ -295,13 +316,13 @@ func specify_v1(e Entry) interface{} {
         }
         ans.TargetInfo = nfo
     }
-    gs := e.Virus != "" || e.Spyware != "" || e.Vulnerability != "" || e.UrlFiltering != "" || e.FileBlocking != "" || e.WildFireAnalysis != "" || e.DataFiltering != ""
-    if e.Group != "" || gs {
-        ps := &profileSettings{
+    if e.Group != "" {
+        ans.ProfileSettings = &profileSettings{
             Group: util.OneStrToMem(e.Group),
         }
-        if gs {
-            ps.Profiles = &profileSettingsProfile{
+    } else if e.Virus != "" || e.Spyware != "" || e.Vulnerability != "" || e.UrlFiltering != "" || e.FileBlocking != "" || e.WildFireAnalysis != "" || e.DataFiltering != "" {
+        ans.ProfileSettings = &profileSettings{
+            Profiles: &profileSettingsProfile{
                 util.OneStrToMem(e.Virus),
                 util.OneStrToMem(e.Spyware),
                 util.OneStrToMem(e.Vulnerability),
@@ -309,9 +330,8 @@ func specify_v1(e Entry) interface{} {
                 util.OneStrToMem(e.FileBlocking),
                 util.OneStrToMem(e.WildFireAnalysis),
                 util.OneStrToMem(e.DataFiltering),
-            }
+            },
         }
-        ans.ProfileSettings = ps
     }
 
     return ans
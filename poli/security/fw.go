@@ -58,6 +58,9 @@ func (c *FwSecurity) Set(vsys string, e ...Entry) error {
             if m[e[i].Name] > 1 {
                 return fmt.Errorf("Security rule is defined multiple times: %s", e[i].Name)
             }
+            if err = e[i].profileError(); err != nil {
+                return err
+            }
         }
     }
 
@@ -135,6 +138,10 @@ func (c *FwSecurity) VerifiableSet(vsys string, e ...Entry) error {
 func (c *FwSecurity) Edit(vsys string, e Entry) error {
     var err error
 
+    if err = e.profileError(); err != nil {
+        return err
+    }
+
     _, fn := c.versioning()
 
     c.con.LogAction("(edit) security policy %q", e.Name)
@@ -31,6 +31,20 @@ func TestFwNormalization(t *testing.T) {
             },
             NegateTarget: true,
         }},
+        {"rule with profile group", "", true, Entry{
+            Name: "rule4",
+            Group: "default",
+        }},
+        {"rule with profiles", "", true, Entry{
+            Name: "rule5",
+            Virus: "av",
+            Spyware: "as",
+            Vulnerability: "vp",
+            UrlFiltering: "url",
+            FileBlocking: "fb",
+            WildFireAnalysis: "wf",
+            DataFiltering: "df",
+        }},
     }
 
     mc := &testdata.MockClient{}
@@ -60,3 +74,26 @@ func TestFwNormalization(t *testing.T) {
     }
 }
 
+func TestFwProfileGroupExclusive(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &FwSecurity{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    e := Entry{
+        Name: "rule1",
+        Group: "default",
+        Virus: "av",
+    }
+    e.Defaults()
+
+    if err := ns.Set("", e); err == nil {
+        t.Errorf("Set did not return an error")
+    }
+    if err := ns.Edit("", e); err == nil {
+        t.Errorf("Edit did not return an error")
+    }
+    if mc.Called != 0 {
+        t.Errorf("Expected no API calls, got %d", mc.Called)
+    }
+}
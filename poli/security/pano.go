@@ -58,6 +58,9 @@ func (c *PanoSecurity) Set(dg, base string, e ...Entry) error {
             if m[e[i].Name] > 1 {
                 return fmt.Errorf("Security rule is defined multiple times: %s", e[i].Name)
             }
+            if err = e[i].profileError(); err != nil {
+                return err
+            }
         }
     }
 
@@ -135,6 +138,10 @@ func (c *PanoSecurity) VerifiableSet(dg, base string, e ...Entry) error {
 func (c *PanoSecurity) Edit(dg, base string, e Entry) error {
     var err error
 
+    if err = e.profileError(); err != nil {
+        return err
+    }
+
     _, fn := c.versioning()
 
     c.con.LogAction("(edit) security policy %q", e.Name)
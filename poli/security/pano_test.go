@@ -33,6 +33,20 @@ func TestPanoNormalization(t *testing.T) {
             },
             NegateTarget: true,
         }},
+        {"rule with profile group", "", util.PreRulebase, true, Entry{
+            Name: "rule4",
+            Group: "default",
+        }},
+        {"rule with profiles", "", util.PostRulebase, true, Entry{
+            Name: "rule5",
+            Virus: "av",
+            Spyware: "as",
+            Vulnerability: "vp",
+            UrlFiltering: "url",
+            FileBlocking: "fb",
+            WildFireAnalysis: "wf",
+            DataFiltering: "df",
+        }},
     }
 
     mc := &testdata.MockClient{}
@@ -62,3 +76,27 @@ func TestPanoNormalization(t *testing.T) {
     }
 }
 
+
+func TestPanoProfileGroupExclusive(t *testing.T) {
+    mc := &testdata.MockClient{}
+    ns := &PanoSecurity{}
+    ns.Initialize(mc)
+    mc.AddResp("")
+
+    e := Entry{
+        Name: "rule1",
+        Group: "default",
+        DataFiltering: "df",
+    }
+    e.Defaults()
+
+    if err := ns.Set("", util.PreRulebase, e); err == nil {
+        t.Errorf("Set did not return an error")
+    }
+    if err := ns.Edit("", util.PreRulebase, e); err == nil {
+        t.Errorf("Edit did not return an error")
+    }
+    if mc.Called != 0 {
+        t.Errorf("Expected no API calls, got %d", mc.Called)
+    }
+}
@@ -0,0 +1,268 @@
+package pango
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/inwinstack/pango/util"
+)
+
+// PushRequest describes a Panorama push of config to managed devices, also
+// known as a "commit-all".
+//
+// Exactly one of DeviceGroup, Template, TemplateStack, or CollectorGroup
+// must be specified.
+type PushRequest struct {
+	Description string
+
+	DeviceGroup    string
+	Template       string
+	TemplateStack  string
+	CollectorGroup string
+
+	// Devices limits the push to the given serial numbers.  It is not valid
+	// for collector groups.
+	Devices []string
+
+	// IncludeTemplate pushes the template config along with the device group
+	// config.  It is only valid for device groups.
+	IncludeTemplate bool
+
+	// ForceTemplateValues overwrites the local config on the devices with the
+	// template values.  It is not valid for collector groups.
+	ForceTemplateValues bool
+
+	// Merge merges the pushed config with the candidate config of the devices.
+	// It is not valid for collector groups.
+	Merge bool
+}
+
+// PushResult is the push result for a single device.
+type PushResult struct {
+	Serial     string
+	DeviceName string
+	Result     string
+	Status     string
+	Warnings   []string
+	Errors     []string
+}
+
+// Failed returns true if the push to this device failed.
+func (o PushResult) Failed() bool {
+	return o.Result != "OK" && o.Result != "PEND"
+}
+
+// PushError is returned from Push() when the push failed on one or more
+// devices.
+type PushError struct {
+	Job    uint
+	Failed []PushResult
+}
+
+func (e PushError) Error() string {
+	list := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		var msg string
+		switch {
+		case len(r.Errors) > 0:
+			msg = strings.Join(r.Errors, "; ")
+		case r.Status != "":
+			msg = r.Status
+		default:
+			msg = r.Result
+		}
+		if r.DeviceName != "" {
+			list = append(list, fmt.Sprintf("%s (%s): %s", r.Serial, r.DeviceName, msg))
+		} else {
+			list = append(list, fmt.Sprintf("%s: %s", r.Serial, msg))
+		}
+	}
+
+	return fmt.Sprintf("Job %d failed on %d device(s): %s", e.Job, len(e.Failed), strings.Join(list, ", "))
+}
+
+// Push pushes Panorama config to managed devices.
+//
+// Param sync should be true if you want this function to block until the
+// push job completes on all devices, in which case the per device results
+// are also returned.  If the push failed on any device, then the error
+// returned is a PushError listing those devices.
+//
+// The job ID is returned, which is 0 if no push was needed.
+func (c *Panorama) Push(req PushRequest, sync bool) (uint, []PushResult, error) {
+	cmd, err := req.element()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	c.LogAction("(commit-all) %q", req.Description)
+	job, _, err := c.CommitConfig(cmd, "all", nil)
+	if err != nil || !sync || job == 0 {
+		return job, nil, err
+	}
+
+	waitErr := c.WaitForJob(job, nil)
+	results, err := c.PushResults(job)
+	if err != nil {
+		if waitErr != nil {
+			return job, nil, waitErr
+		}
+		return job, nil, err
+	}
+
+	failed := make([]PushResult, 0, len(results))
+	for _, r := range results {
+		if r.Failed() {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		return job, results, PushError{Job: job, Failed: failed}
+	}
+
+	return job, results, waitErr
+}
+
+// PushResults returns the per device results of the given push job.
+func (c *Panorama) PushResults(job uint) ([]PushResult, error) {
+	type req struct {
+		XMLName xml.Name `xml:"show"`
+		Id      uint     `xml:"jobs>id"`
+	}
+
+	c.LogOp("(op) getting push results for job %d", job)
+	ans := pushJob{}
+	if _, err := c.Op(req{Id: job}, "", nil, &ans); err != nil {
+		return nil, err
+	}
+
+	list := make([]PushResult, 0, len(ans.Devices))
+	for _, d := range ans.Devices {
+		r := PushResult{
+			Serial:     d.Serial,
+			DeviceName: d.DeviceName,
+			Result:     d.Result,
+			Status:     d.Status,
+			Warnings:   d.Warnings,
+			Errors:     d.Errors,
+		}
+		if len(d.Lines) > 0 {
+			if r.Failed() {
+				r.Errors = append(r.Errors, d.Lines...)
+			} else {
+				r.Warnings = append(r.Warnings, d.Lines...)
+			}
+		}
+		list = append(list, r)
+	}
+
+	return list, nil
+}
+
+func (o PushRequest) element() (interface{}, error) {
+	var count int
+	for _, v := range []string{o.DeviceGroup, o.Template, o.TemplateStack, o.CollectorGroup} {
+		if v != "" {
+			count++
+		}
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("exactly one of device group, template, template stack, or collector group must be specified")
+	}
+
+	ans := pushCommitAll{}
+	switch {
+	case o.DeviceGroup != "":
+		ans.Policy = &pushSharedPolicy{
+			Description:     o.Description,
+			IncludeTemplate: util.YesNo(o.IncludeTemplate),
+		}
+		ans.Policy.Dg.Entry = deviceGroupEntry{
+			Name:    o.DeviceGroup,
+			Devices: util.StrToEnt(o.Devices),
+		}
+		if o.ForceTemplateValues {
+			ans.Policy.ForceTemplateValues = "yes"
+		}
+		if o.Merge {
+			ans.Policy.Merge = "yes"
+		}
+	case o.Template != "", o.TemplateStack != "":
+		if o.IncludeTemplate {
+			return nil, fmt.Errorf("include template is only valid for device groups")
+		}
+		t := &pushTemplate{
+			Name:        o.Template + o.TemplateStack,
+			Description: o.Description,
+			Devices:     util.StrToMem(o.Devices),
+		}
+		if o.ForceTemplateValues {
+			t.ForceTemplateValues = "yes"
+		}
+		if o.Merge {
+			t.Merge = "yes"
+		}
+		if o.Template != "" {
+			ans.Template = t
+		} else {
+			ans.TemplateStack = t
+		}
+	default:
+		if len(o.Devices) > 0 || o.IncludeTemplate || o.ForceTemplateValues || o.Merge {
+			return nil, fmt.Errorf("devices and push options are not valid for collector groups")
+		}
+		ans.Collector = &pushCollector{
+			Group:       o.CollectorGroup,
+			Description: o.Description,
+		}
+	}
+
+	return ans, nil
+}
+
+/** Internal structs **/
+
+type pushCommitAll struct {
+	XMLName       xml.Name          `xml:"commit-all"`
+	Policy        *pushSharedPolicy `xml:"shared-policy"`
+	Template      *pushTemplate     `xml:"template"`
+	TemplateStack *pushTemplate     `xml:"template-stack"`
+	Collector     *pushCollector    `xml:"log-collector-config"`
+}
+
+type pushSharedPolicy struct {
+	Dg                  deviceGroup `xml:"device-group"`
+	Description         string      `xml:"description,omitempty"`
+	IncludeTemplate     string      `xml:"include-template"`
+	ForceTemplateValues string      `xml:"force-template-values,omitempty"`
+	Merge               string      `xml:"merge-with-candidate-cfg,omitempty"`
+}
+
+type pushTemplate struct {
+	Name                string           `xml:"name"`
+	Description         string           `xml:"description,omitempty"`
+	Devices             *util.MemberType `xml:"device"`
+	ForceTemplateValues string           `xml:"force-template-values,omitempty"`
+	Merge               string           `xml:"merge-with-candidate-cfg,omitempty"`
+}
+
+type pushCollector struct {
+	Group       string `xml:"log-collector-group"`
+	Description string `xml:"description,omitempty"`
+}
+
+type pushJob struct {
+	XMLName xml.Name        `xml:"response"`
+	Devices []pushJobDevice `xml:"result>job>devices>entry"`
+}
+
+type pushJobDevice struct {
+	Serial     string   `xml:"serial-no"`
+	DeviceName string   `xml:"devicename"`
+	Result     string   `xml:"result"`
+	Status     string   `xml:"status"`
+	Warnings   []string `xml:"details>msg>warnings>line"`
+	Errors     []string `xml:"details>msg>errors>line"`
+	Lines      []string `xml:"details>line"`
+}
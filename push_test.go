@@ -0,0 +1,109 @@
+package pango
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const pushJobXml = `<response status="success"><result><job>
+    <id>9</id><type>CommitAll</type><status>FIN</status><result>OK</result><progress>100</progress>
+    <devices>
+        <entry>
+            <serial-no>0001</serial-no><devicename>fw1</devicename><result>OK</result><status>commit succeeded with warnings</status>
+            <details><msg><warnings><line>rule a shadows rule b</line></warnings></msg></details>
+        </entry>
+        <entry>
+            <serial-no>0002</serial-no><devicename>fw2</devicename><result>FAIL</result><status>commit failed</status>
+            <details><msg><errors><line>zone trust is invalid</line><line>commit failed</line></errors></msg></details>
+        </entry>
+    </devices>
+</job></result></response>`
+
+func TestPushRequestElement(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		req      PushRequest
+		expected string
+	}{
+		{"device group", PushRequest{DeviceGroup: "dg1", Devices: []string{"0001"}, IncludeTemplate: true, Merge: true},
+			`<commit-all><shared-policy><device-group><entry name="dg1"><devices><entry name="0001"></entry></devices></entry></device-group><include-template>yes</include-template><merge-with-candidate-cfg>yes</merge-with-candidate-cfg></shared-policy></commit-all>`},
+		{"template", PushRequest{Template: "t1", ForceTemplateValues: true, Description: "hi"},
+			`<commit-all><template><name>t1</name><description>hi</description><force-template-values>yes</force-template-values></template></commit-all>`},
+		{"template stack", PushRequest{TemplateStack: "ts1", Devices: []string{"0001", "0002"}},
+			`<commit-all><template-stack><name>ts1</name><device><member>0001</member><member>0002</member></device></template-stack></commit-all>`},
+		{"collector group", PushRequest{CollectorGroup: "default"},
+			`<commit-all><log-collector-config><log-collector-group>default</log-collector-group></log-collector-config></commit-all>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &Panorama{Client: Client{Logging: LogQuiet}}
+			c.setResponses([][]byte{[]byte(`<response status="success" code="19"><result><job>9</job></result></response>`)})
+
+			if job, _, err := c.Push(tc.req, false); err != nil {
+				t.Fatalf("Error in push: %s", err)
+			} else if job != 9 {
+				t.Errorf("Expected job 9, got %d", job)
+			}
+			if c.rp[0].Get("action") != "all" {
+				t.Errorf("Unexpected action: %s", c.rp[0].Get("action"))
+			} else if cmd := c.rp[0].Get("cmd"); cmd != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, cmd)
+			}
+		})
+	}
+}
+
+func TestPushRequestInvalid(t *testing.T) {
+	testCases := []struct {
+		desc string
+		req  PushRequest
+	}{
+		{"no target", PushRequest{}},
+		{"two targets", PushRequest{DeviceGroup: "dg1", Template: "t1"}},
+		{"template include template", PushRequest{Template: "t1", IncludeTemplate: true}},
+		{"collector group devices", PushRequest{CollectorGroup: "default", Devices: []string{"0001"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := tc.req.element(); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestPushResults(t *testing.T) {
+	c := &Panorama{Client: Client{Logging: LogQuiet}}
+	c.setResponses([][]byte{
+		[]byte(`<response status="success" code="19"><result><job>9</job></result></response>`),
+		[]byte(pushJobXml),
+		[]byte(pushJobXml),
+	})
+
+	job, results, err := c.Push(PushRequest{DeviceGroup: "dg1"}, true)
+	if job != 9 {
+		t.Errorf("Expected job 9, got %d", job)
+	}
+
+	var pe PushError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected a PushError, got %#v", err)
+	} else if len(pe.Failed) != 1 || pe.Failed[0].Serial != "0002" {
+		t.Errorf("Unexpected failed devices: %#v", pe.Failed)
+	} else if !strings.Contains(err.Error(), "0002 (fw2): zone trust is invalid; commit failed") {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if r := results[0]; r.Failed() || r.DeviceName != "fw1" || len(r.Warnings) != 1 || r.Warnings[0] != "rule a shadows rule b" {
+		t.Errorf("Unexpected result: %#v", r)
+	}
+	if r := results[1]; !r.Failed() || r.Status != "commit failed" || len(r.Errors) != 2 {
+		t.Errorf("Unexpected result: %#v", r)
+	}
+}
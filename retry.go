@@ -0,0 +1,140 @@
+package pango
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides if a failed API call should be attempted again.
+//
+// Retry is invoked after each failed attempt with the number of attempts made
+// so far (starting at 1) and the error encountered.  It returns how long to
+// wait before the next attempt, and whether another attempt should be made at
+// all.
+type RetryPolicy interface {
+	Retry(attempt int, err error) (time.Duration, bool)
+}
+
+// BackoffRetry is a RetryPolicy that retries transient failures with
+// exponential backoff and optional jitter.
+//
+// If not specified, the following is assumed:
+//  * MaxAttempts: 5
+//  * BaseDelay: 1 second
+//  * MaxDelay: 30 seconds
+//  * Retryable: IsRetryable
+type BackoffRetry struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      bool
+	Retryable   func(error) bool
+}
+
+// Retry implements the RetryPolicy interface.
+func (o BackoffRetry) Retry(attempt int, err error) (time.Duration, bool) {
+	max := o.MaxAttempts
+	if max == 0 {
+		max = 5
+	}
+	base := o.BaseDelay
+	if base == 0 {
+		base = time.Second
+	}
+	limit := o.MaxDelay
+	if limit == 0 {
+		limit = 30 * time.Second
+	}
+	fn := o.Retryable
+	if fn == nil {
+		fn = IsRetryable
+	}
+
+	if attempt >= max || !fn(err) {
+		return 0, false
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+
+	if o.Jitter && delay > 1 {
+		half := delay / 2
+		delay = half + time.Duration(rand.Int63n(int64(delay-half)))
+	}
+
+	return delay, true
+}
+
+// HttpError is returned when PAN-OS responds with a HTTP server error status
+// instead of an XML API response.
+type HttpError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+// Error returns the error message.
+func (e HttpError) Error() string {
+	return fmt.Sprintf("HTTP error: %s", e.Status)
+}
+
+// IsRetryable returns true if the given error is considered transient, meaning
+// that the same API call may succeed if attempted again later.
+//
+// The following are considered transient:
+//  * network timeouts, connection resets and refused connections
+//  * HTTP 5xx responses
+//  * PAN-OS responses stating that a commit is in progress or the config
+//    is locked
+func IsRetryable(err error) bool {
+	var he HttpError
+	var pe PanosError
+
+	switch {
+	case err == nil:
+		return false
+	case errors.As(err, &he):
+		return he.StatusCode >= 500
+	case errors.As(err, &pe):
+		return pe.CommitInProgress() || pe.ConfigLocked()
+	}
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		return true
+	}
+
+	return isConnError(err)
+}
+
+// isConnError checks if the root cause of err is a connection reset or a
+// refused connection.
+func isConnError(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case syscall.Errno:
+			return e == syscall.ECONNRESET || e == syscall.ECONNREFUSED || e == syscall.ECONNABORTED
+		case *net.OpError:
+			err = e.Err
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
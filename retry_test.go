@@ -0,0 +1,109 @@
+package pango
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestBackoffRetryDelays(t *testing.T) {
+	p := BackoffRetry{
+		MaxAttempts: 4,
+		BaseDelay:   time.Second,
+		MaxDelay:    3 * time.Second,
+	}
+	err := HttpError{StatusCode: 503, Status: "503 Service Unavailable"}
+
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		d, ok := p.Retry(i+1, err)
+		if !ok {
+			t.Errorf("attempt %d: expected a retry", i+1)
+		} else if d != want {
+			t.Errorf("attempt %d: expected delay %s, got %s", i+1, want, d)
+		}
+	}
+
+	if _, ok := p.Retry(4, err); ok {
+		t.Errorf("Retried past MaxAttempts")
+	}
+}
+
+func TestBackoffRetryJitter(t *testing.T) {
+	p := BackoffRetry{BaseDelay: 8 * time.Second, Jitter: true}
+	for i := 0; i < 20; i++ {
+		d, ok := p.Retry(1, io.EOF)
+		if !ok || d < 4*time.Second || d > 8*time.Second {
+			t.Errorf("Jittered delay out of range: %s %t", d, ok)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		desc string
+		err  error
+		ans  bool
+	}{
+		{"nil", nil, false},
+		{"commit in progress", PanosError{Msg: "Another commit is in progress. Please try again later"}, true},
+		{"config locked", PanosError{Msg: "Config for scope shared is currently locked by admin"}, true},
+		{"object not found", PanosError{Msg: "Object not found", Code: 7}, false},
+		{"http 502", HttpError{StatusCode: 502}, true},
+		{"http 404", HttpError{StatusCode: 404}, false},
+		{"unexpected eof", io.ErrUnexpectedEOF, true},
+		{"connection reset", &url.Error{Op: "Post", Err: syscall.ECONNRESET}, true},
+		{"other", fmt.Errorf("some error"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if IsRetryable(tc.err) != tc.ans {
+				t.Errorf("Expected %t for %v", tc.ans, tc.err)
+			}
+		})
+	}
+}
+
+func TestCommunicateRetries(t *testing.T) {
+	c := &Client{}
+	c.setResponses([][]byte{
+		[]byte(`<response status="error"><msg><line>Another commit is in progress. Please try again later</line></msg></response>`),
+		[]byte(`<response status="success"><result><job>5</job></result></response>`),
+	})
+	if err := c.Initialize(); err != nil {
+		t.Errorf("Initialize failed: %s", err)
+		return
+	}
+	c.Retry = BackoffRetry{BaseDelay: time.Millisecond}
+
+	job, err := c.Commit("", nil, true, true, false, false)
+	if err != nil {
+		t.Errorf("Error in commit: %s", err)
+	} else if job != 5 {
+		t.Errorf("Expected job 5, got %d", job)
+	} else if len(c.rp) != 2 {
+		t.Errorf("Expected 2 attempts, got %d", len(c.rp))
+	}
+}
+
+func TestCommunicateDoesNotRetryFatalErrors(t *testing.T) {
+	c := &Client{}
+	c.setResponses([][]byte{
+		[]byte(`<response status="error" code="7"><msg><line>No such node</line></msg></response>`),
+		[]byte(`<response status="success"><result /></response>`),
+	})
+	if err := c.Initialize(); err != nil {
+		t.Errorf("Initialize failed: %s", err)
+		return
+	}
+	c.Retry = BackoffRetry{BaseDelay: time.Millisecond}
+
+	if _, err := c.Delete("/config/shared/address/entry[@name='foo']", nil, nil); err == nil {
+		t.Errorf("Expected an error")
+	} else if len(c.rp) != 1 {
+		t.Errorf("Expected 1 attempt, got %d", len(c.rp))
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Package sim is a local, in-process simulator of the PAN-OS XML API.
+
+It keeps a real XML candidate and running config, and honors the config
+actions (get, show, set, edit, delete, move, rename, and multi-config) against
+the given xpaths, returning the same success and error envelopes that PAN-OS does.
+Together with API key generation, "show system info", commits, job
+polling, and saving, loading, importing, and exporting config files, this
+allows pango to be run end to end without a device:
+
+    s := sim.NewFirewall("9.0.0")
+    s.Start()
+    defer s.Close()
+
+    fw := &pango.Firewall{Client: pango.Client{
+        Hostname: s.Hostname(),
+        Protocol: "http",
+        Username: sim.DefaultUsername,
+        Password: sim.DefaultPassword,
+        Logging: pango.LogQuiet,
+    }}
+    if err := fw.Initialize(); err != nil {
+        ...
+    }
+
+Only the subset of xpath that pango generates is supported.
+*/
+package sim
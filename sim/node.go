@@ -0,0 +1,247 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// node is a single element of an XML document.
+type node struct {
+	Name     string
+	Attrs    []xml.Attr
+	Text     string
+	Children []*node
+	Parent   *node
+}
+
+// newNode returns a node with the given tag name, and if non-empty, name
+// attribute.
+func newNode(tag, name string) *node {
+	n := &node{Name: tag}
+	if name != "" {
+		n.SetAttr("name", name)
+	}
+	return n
+}
+
+// Attr returns the value of the given attribute.
+func (n *node) Attr(key string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == key {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// SetAttr sets the value of the given attribute.
+func (n *node) SetAttr(key, value string) {
+	for i := range n.Attrs {
+		if n.Attrs[i].Name.Local == key {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: key}, Value: value})
+}
+
+// Append adds the given node as the last child of this node.
+func (n *node) Append(c *node) {
+	c.Parent = n
+	n.Children = append(n.Children, c)
+}
+
+// Index returns the position of this node in its parent's children.
+func (n *node) Index() int {
+	if n.Parent == nil {
+		return -1
+	}
+	for i, c := range n.Parent.Children {
+		if c == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// Remove detaches this node from its parent.
+func (n *node) Remove() {
+	if idx := n.Index(); idx != -1 {
+		p := n.Parent
+		p.Children = append(p.Children[:idx], p.Children[idx+1:]...)
+	}
+	n.Parent = nil
+}
+
+// Copy returns a deep copy of this node, without a parent.
+func (n *node) Copy() *node {
+	ans := &node{
+		Name:  n.Name,
+		Attrs: append([]xml.Attr(nil), n.Attrs...),
+		Text:  n.Text,
+	}
+	for _, c := range n.Children {
+		ans.Append(c.Copy())
+	}
+	return ans
+}
+
+// Same returns true if the given node refers to the same config object as
+// this node: entries are matched on name, members on value, and everything
+// else on tag name.
+func (n *node) Same(o *node) bool {
+	if n.Name != o.Name {
+		return false
+	}
+
+	switch n.Name {
+	case "entry":
+		return n.Attr("name") == o.Attr("name")
+	case "member":
+		return n.Text == o.Text
+	}
+
+	return true
+}
+
+// Merge merges the given node's children into this node, the way that PAN-OS
+// handles a "set" action.
+func (n *node) Merge(src *node) {
+	if len(src.Children) == 0 && src.Text != "" {
+		n.Text = src.Text
+	}
+
+	for _, a := range src.Attrs {
+		n.SetAttr(a.Name.Local, a.Value)
+	}
+
+	for _, sc := range src.Children {
+		var dc *node
+		for _, c := range n.Children {
+			if c.Same(sc) {
+				dc = c
+				break
+			}
+		}
+
+		if dc == nil {
+			n.Append(sc.Copy())
+		} else {
+			dc.Merge(sc)
+		}
+	}
+}
+
+// Equal checks if this node and the given node have identical contents.
+func (n *node) Equal(o *node) bool {
+	return n.String() == o.String()
+}
+
+// String returns the XML representation of this node.
+func (n *node) String() string {
+	var buf bytes.Buffer
+	n.write(&buf)
+	return buf.String()
+}
+
+func (n *node) write(buf *bytes.Buffer) {
+	buf.WriteString("<")
+	buf.WriteString(n.Name)
+	for _, a := range n.Attrs {
+		fmt.Fprintf(buf, " %s=\"", a.Name.Local)
+		xml.EscapeText(buf, []byte(a.Value))
+		buf.WriteString("\"")
+	}
+
+	if len(n.Children) == 0 && n.Text == "" {
+		buf.WriteString("/>")
+		return
+	}
+
+	buf.WriteString(">")
+	if len(n.Children) == 0 {
+		xml.EscapeText(buf, []byte(n.Text))
+	}
+	for _, c := range n.Children {
+		c.write(buf)
+	}
+	buf.WriteString("</")
+	buf.WriteString(n.Name)
+	buf.WriteString(">")
+}
+
+// parseFragment parses the given XML, which may contain multiple top level
+// elements, returning the top level elements.
+func parseFragment(s string) ([]*node, error) {
+	root, err := parseDocument(fmt.Sprintf("<fragment>%s</fragment>", s))
+	if err != nil {
+		return nil, err
+	}
+
+	ans := root.Children
+	for _, c := range ans {
+		c.Parent = nil
+	}
+	return ans, nil
+}
+
+// parseDocument parses the given XML document, returning the root element.
+func parseDocument(s string) (*node, error) {
+	var root, cur *node
+
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{Name: t.Name.Local}
+			for _, a := range t.Attr {
+				n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: a.Name.Local}, Value: a.Value})
+			}
+			if cur == nil {
+				if root != nil {
+					return nil, fmt.Errorf("multiple root elements")
+				}
+				root = n
+			} else {
+				cur.Append(n)
+			}
+			cur = n
+		case xml.EndElement:
+			cur = cur.Parent
+		case xml.CharData:
+			if cur != nil {
+				cur.Text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("no elements found")
+	}
+
+	root.trim()
+	return root, nil
+}
+
+// trim removes the whitespace between child elements and inside of empty
+// elements.
+func (n *node) trim() {
+	if len(n.Children) > 0 {
+		n.Text = ""
+		for _, c := range n.Children {
+			c.trim()
+		}
+	} else if strings.TrimSpace(n.Text) == "" {
+		n.Text = ""
+	}
+}
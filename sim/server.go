@@ -0,0 +1,661 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Default credentials accepted by the simulator.
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "admin"
+	DefaultApiKey   = "simulated-api-key"
+)
+
+const firewallConfig = `<config version="%s">
+    <mgt-config><users><entry name="admin"/></users></mgt-config>
+    <shared/>
+    <devices><entry name="localhost.localdomain">
+        <deviceconfig><system><hostname>%s</hostname></system></deviceconfig>
+        <network/>
+        <vsys><entry name="vsys1"/></vsys>
+    </entry></devices>
+</config>`
+
+const panoramaConfig = `<config version="%s">
+    <mgt-config><users><entry name="admin"/></users></mgt-config>
+    <shared/>
+    <devices><entry name="localhost.localdomain">
+        <deviceconfig><system><hostname>%s</hostname></system></deviceconfig>
+        <device-group/>
+        <template/>
+        <template-stack/>
+    </entry></devices>
+</config>`
+
+// Server is a simulated PAN-OS device.  It keeps a candidate and a running
+// config in memory and answers PAN-OS XML API requests against them.
+//
+// Config "get" requests read the candidate config, while "show" requests
+// read the running config, just like on a real device.  A commit copies the
+// candidate config to the running config and submits a job that is
+// immediately complete.
+//
+// Server implements http.Handler, so it can be used with any HTTP server or
+// with the Start() convenience function.
+type Server struct {
+	// Credentials.
+	Username string
+	Password string
+	ApiKey   string
+
+	// SystemInfo is returned from "show system info".
+	SystemInfo map[string]string
+
+	// OpResponses are canned responses for op commands that the simulator
+	// does not natively support.  The key is the op command's XML and the
+	// value is the contents of the response's result node.
+	OpResponses map[string]string
+
+	mu        sync.Mutex
+	candidate *node
+	running   *node
+	jobs      []job
+	saved     map[string]*node
+	ts        *httptest.Server
+}
+
+// job is a job submitted to the simulator.
+type job struct {
+	Type    string
+	Details []string
+}
+
+// NewFirewall returns a simulated firewall running the given PAN-OS version.
+func NewFirewall(version string) *Server {
+	return newServer(version, "PA-VM", "sim-fw", firewallConfig)
+}
+
+// NewPanorama returns a simulated Panorama running the given PAN-OS version.
+func NewPanorama(version string) *Server {
+	return newServer(version, "Panorama", "sim-pano", panoramaConfig)
+}
+
+func newServer(version, model, hostname, conf string) *Server {
+	s := &Server{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		ApiKey:   DefaultApiKey,
+		SystemInfo: map[string]string{
+			"hostname":   hostname,
+			"model":      model,
+			"serial":     "unknown",
+			"sw-version": version,
+		},
+		OpResponses: make(map[string]string),
+		saved:       make(map[string]*node),
+	}
+
+	if err := s.LoadConfig(fmt.Sprintf(conf, version, hostname)); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// LoadConfig replaces both the candidate and running config with the given
+// XML document, whose root node should be "config".
+func (s *Server) LoadConfig(doc string) error {
+	root, err := parseDocument(doc)
+	if err != nil {
+		return err
+	} else if root.Name != "config" {
+		return fmt.Errorf("root node is %q, not \"config\"", root.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.candidate = &node{}
+	s.candidate.Append(root)
+	s.running = s.candidate.Copy()
+	return nil
+}
+
+// CandidateConfig returns the candidate config as XML.
+func (s *Server) CandidateConfig() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.candidate.Children[0].String()
+}
+
+// RunningConfig returns the running config as XML.
+func (s *Server) RunningConfig() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running.Children[0].String()
+}
+
+// Start serves the simulator over HTTP on a local port.  Clients should use
+// the "http" protocol and Hostname() as their hostname.
+func (s *Server) Start() {
+	s.ts = httptest.NewServer(s)
+}
+
+// Hostname returns the host:port the simulator is listening on.
+func (s *Server) Hostname() string {
+	if s.ts == nil {
+		return ""
+	}
+	return s.ts.Listener.Addr().String()
+}
+
+// Close stops serving the simulator.
+func (s *Server) Close() {
+	if s.ts != nil {
+		s.ts.Close()
+		s.ts = nil
+	}
+}
+
+// ServeHTTP implements the http.Handler interface, answering requests made to
+// the "/api" endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err = r.ParseMultipartForm(32 << 20)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=UTF-8")
+	code, body := s.handle(r)
+	w.WriteHeader(code)
+	w.Write([]byte(body))
+}
+
+func (s *Server) handle(r *http.Request) (int, string) {
+	if r.URL.Path != "/api" && r.URL.Path != "/api/" {
+		return http.StatusNotFound, errorResponse(0, "Not found")
+	}
+
+	if r.Form.Get("type") == "keygen" {
+		if r.Form.Get("user") != s.Username || r.Form.Get("password") != s.Password {
+			return http.StatusForbidden, authFailure()
+		}
+		return http.StatusOK, successResult(fmt.Sprintf("<key>%s</key>", s.ApiKey))
+	}
+
+	if r.Form.Get("key") != s.ApiKey {
+		return http.StatusForbidden, authFailure()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Form.Get("type") {
+	case "config":
+		return http.StatusOK, s.config(r.Form.Get("action"), r.Form)
+	case "op":
+		if r.Form.Get("action") == "complete" {
+			return http.StatusOK, s.complete(r.Form.Get("xpath"))
+		}
+		return http.StatusOK, s.op(r.Form.Get("cmd"))
+	case "commit":
+		return http.StatusOK, s.commit()
+	case "user-id":
+		return http.StatusOK, successResult("<uid-response><version>2.0</version><payload/></uid-response>")
+	case "import":
+		return http.StatusOK, s.importFile(r)
+	case "export":
+		if r.Form.Get("category") == "configuration" {
+			return http.StatusOK, s.running.Children[0].String()
+		}
+	}
+
+	return http.StatusOK, errorResponse(17, "Invalid type")
+}
+
+// config handles a type=config request.
+func (s *Server) config(action string, form map[string][]string) string {
+	get := func(k string) string {
+		if v := form[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	if action == "multi-config" {
+		return s.multiConfig(get("element"))
+	}
+
+	return s.configAction(s.candidate, action, get("xpath"), get)
+}
+
+// configAction performs a single config action against the given config.
+func (s *Server) configAction(root *node, action, xpath string, get func(string) string) string {
+	steps, err := parseXpath(xpath)
+	if err != nil {
+		return errorResponse(6, fmt.Sprintf("Bad Xpath: %s", err))
+	}
+
+	switch action {
+	case "get":
+		return s.configGet(root, steps, true)
+	case "show":
+		return s.configGet(s.running, steps, false)
+	case "set":
+		return s.configSet(root, steps, get("element"))
+	case "edit":
+		return s.configEdit(root, steps, get("element"))
+	case "delete":
+		return s.configDelete(root, steps)
+	case "move":
+		return s.configMove(root, steps, get("where"), get("dst"))
+	case "rename":
+		return s.configRename(root, steps, get("newname"))
+	}
+
+	return errorResponse(17, fmt.Sprintf("Invalid action %q", action))
+}
+
+// multiConfig applies all operations in the given multi-configuration
+// element to a copy of the candidate config, replacing the candidate config
+// only if all of them succeed.
+func (s *Server) multiConfig(element string) string {
+	elms, err := parseFragment(element)
+	if err != nil || len(elms) != 1 || elms[0].Name != "multi-configuration" {
+		return errorResponse(18, "Malformed multi-configuration element")
+	}
+
+	root := s.candidate.Copy()
+	ans := &node{Name: "response"}
+	failed := 0
+	for _, op := range elms[0].Children {
+		get := func(k string) string {
+			if k == "element" {
+				var buf bytes.Buffer
+				for _, c := range op.Children {
+					c.write(&buf)
+				}
+				return buf.String()
+			}
+			return op.Attr(k)
+		}
+
+		r, err := parseDocument(s.configAction(root, op.Name, op.Attr("xpath"), get))
+		if err != nil {
+			r, _ = parseDocument(errorResponse(18, fmt.Sprintf("Malformed %s response: %s", op.Name, err)))
+		}
+		r.Attrs = append([]xml.Attr{{Name: xml.Name{Local: "id"}, Value: op.Attr("id")}}, r.Attrs...)
+		ans.Append(r)
+
+		if r.Attr("status") == "error" || (r.Attr("code") != "" && r.Attr("code") != "19" && r.Attr("code") != "20") {
+			failed++
+			ans.SetAttr("status", "error")
+			ans.SetAttr("code", r.Attr("code"))
+			break
+		}
+	}
+
+	if failed == 0 {
+		s.candidate = root
+		ans.SetAttr("status", "success")
+		ans.SetAttr("code", "20")
+	}
+
+	return ans.String()
+}
+
+func (s *Server) configGet(root *node, steps []step, isGet bool) string {
+	nodes := find(root, steps)
+	if len(nodes) == 0 {
+		if isGet {
+			return `<response status="success" code="7"><result/></response>`
+		}
+		return errorResponse(7, "No such node")
+	}
+
+	attr := steps[len(steps)-1].Attr
+	var buf bytes.Buffer
+	if isGet {
+		fmt.Fprintf(&buf, `<response status="success" code="19"><result total-count="%d" count="%d">`, len(nodes), len(nodes))
+	} else {
+		buf.WriteString(`<response status="success"><result>`)
+	}
+	for _, n := range nodes {
+		if attr != "" {
+			n = &node{Name: n.Name, Attrs: []xml.Attr{{Name: xml.Name{Local: attr}, Value: n.Attr(attr)}}}
+		}
+		n.write(&buf)
+	}
+	buf.WriteString("</result></response>")
+
+	return buf.String()
+}
+
+func (s *Server) configSet(root *node, steps []step, element string) string {
+	elms, err := parseFragment(element)
+	if err != nil {
+		return errorResponse(18, fmt.Sprintf("Malformed element: %s", err))
+	}
+
+	parent, err := findOrCreate(root, steps)
+	if err != nil {
+		return errorResponse(6, err.Error())
+	}
+
+	parent.Merge(&node{Children: elms})
+	return commandSucceeded()
+}
+
+func (s *Server) configEdit(root *node, steps []step, element string) string {
+	elms, err := parseFragment(element)
+	if err != nil {
+		return errorResponse(18, fmt.Sprintf("Malformed element: %s", err))
+	}
+
+	last := steps[len(steps)-1]
+	if len(elms) != 1 || !last.Matches(elms[0]) {
+		return errorResponse(12, "edit breaks config validity")
+	}
+
+	cur, err := findOrCreate(root, steps)
+	if err != nil {
+		return errorResponse(6, err.Error())
+	}
+
+	p := cur.Parent
+	idx := cur.Index()
+	elms[0].Parent = p
+	p.Children[idx] = elms[0]
+	return commandSucceeded()
+}
+
+func (s *Server) configDelete(root *node, steps []step) string {
+	nodes := find(root, steps)
+	if len(nodes) == 0 {
+		return `<response status="success" code="7"><msg>Object doesn't exist</msg></response>`
+	}
+
+	for _, n := range nodes {
+		n.Remove()
+	}
+	return commandSucceeded()
+}
+
+func (s *Server) configMove(root *node, steps []step, where, dst string) string {
+	nodes := find(root, steps)
+	if len(nodes) != 1 {
+		return errorResponse(7, "No such node")
+	}
+
+	n := nodes[0]
+	p := n.Parent
+	idx := n.Index()
+
+	switch where {
+	case "top":
+		if idx == 0 {
+			return errorResponse(0, "already at the top")
+		}
+		n.Remove()
+		p.Children = append([]*node{n}, p.Children...)
+	case "bottom":
+		if idx == len(p.Children)-1 {
+			return errorResponse(0, "already at the bottom")
+		}
+		n.Remove()
+		p.Children = append(p.Children, n)
+	case "before", "after":
+		var ref *node
+		for _, c := range p.Children {
+			if c != n && c.Name == n.Name && c.Attr("name") == dst {
+				ref = c
+				break
+			}
+		}
+		if ref == nil {
+			return errorResponse(12, fmt.Sprintf("%s does not exist", dst))
+		}
+		n.Remove()
+		pos := ref.Index()
+		if where == "after" {
+			pos++
+		}
+		p.Children = append(p.Children[:pos], append([]*node{n}, p.Children[pos:]...)...)
+	default:
+		return errorResponse(18, fmt.Sprintf("Invalid where %q", where))
+	}
+	n.Parent = p
+
+	return commandSucceeded()
+}
+
+func (s *Server) configRename(root *node, steps []step, newname string) string {
+	nodes := find(root, steps)
+	if len(nodes) != 1 {
+		return errorResponse(7, "No such node")
+	} else if newname == "" {
+		return errorResponse(18, "newname is required")
+	}
+
+	n := nodes[0]
+	for _, c := range n.Parent.Children {
+		if c != n && c.Name == n.Name && c.Attr("name") == newname {
+			return errorResponse(12, fmt.Sprintf("%s already exists", newname))
+		}
+	}
+
+	n.SetAttr("name", newname)
+	return commandSucceeded()
+}
+
+// op handles a type=op request.
+func (s *Server) op(cmd string) string {
+	if ans, ok := s.OpResponses[cmd]; ok {
+		return successResult(ans)
+	}
+
+	elms, err := parseFragment(cmd)
+	if err != nil || len(elms) != 1 {
+		return errorResponse(18, "Malformed command")
+	}
+
+	// Reduce the command to its keywords and final argument.
+	words := make([]string, 0, 5)
+	var arg string
+	for n := elms[0]; n != nil; {
+		words = append(words, n.Name)
+		arg = n.Text
+		if len(n.Children) == 1 {
+			n = n.Children[0]
+		} else {
+			n = nil
+		}
+	}
+
+	switch strings.Join(words, " ") {
+	case "show system info":
+		var buf bytes.Buffer
+		buf.WriteString("<system>")
+		for k, v := range s.SystemInfo {
+			n := &node{Name: k, Text: v}
+			n.write(&buf)
+		}
+		buf.WriteString("</system>")
+		return successResult(buf.String())
+	case "show plugins packages":
+		return successResult("<plugins/>")
+	case "show config running":
+		return successResult(s.running.Children[0].String())
+	case "show config candidate":
+		return successResult(s.candidate.Children[0].String())
+	case "load config from":
+		src := s.saved[arg]
+		if arg == "running-config.xml" {
+			src = s.running
+		} else if src == nil {
+			return errorResponse(17, fmt.Sprintf("%s does not exist", arg))
+		}
+		s.candidate = src.Copy()
+		return successResult(fmt.Sprintf("<msg><line>Config loaded from %s</line></msg>", arg))
+	case "save config to":
+		s.saved[arg] = s.candidate.Copy()
+		return successResult(fmt.Sprintf("Config saved to %s", arg))
+	case "show config saved":
+		if c := s.saved[arg]; c != nil {
+			return successResult(c.Children[0].String())
+		}
+		return errorResponse(17, fmt.Sprintf("%s does not exist", arg))
+	case "delete config saved":
+		if s.saved[arg] == nil {
+			return errorResponse(17, fmt.Sprintf("%s does not exist", arg))
+		}
+		delete(s.saved, arg)
+		return successResult(fmt.Sprintf("Deleted %s", arg))
+	case "show jobs id":
+		return s.showJob(arg)
+	case "show jobs all":
+		return s.showJobs()
+	}
+
+	return errorResponse(17, "Invalid command")
+}
+
+// complete handles an op request with action=complete, which lists the
+// possible values of a command argument.  Only saved config files are
+// supported.
+func (s *Server) complete(xpath string) string {
+	if xpath != "/operational/show/config/saved" {
+		return errorResponse(17, "Invalid completion")
+	}
+
+	names := make([]string, 0, len(s.saved))
+	for k := range s.saved {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString(`<response status="success"><completions>`)
+	for _, v := range names {
+		n := &node{Name: "completion"}
+		n.SetAttr("value", v)
+		n.write(&buf)
+	}
+	buf.WriteString("</completions></response>")
+	return buf.String()
+}
+
+// importFile handles a type=import request.  Imported configuration files
+// are kept as saved configs.
+func (s *Server) importFile(r *http.Request) string {
+	if r.Form.Get("category") == "configuration" && r.MultipartForm != nil {
+		for _, list := range r.MultipartForm.File {
+			for _, fh := range list {
+				f, err := fh.Open()
+				if err != nil {
+					return errorResponse(0, err.Error())
+				}
+				b, err := ioutil.ReadAll(f)
+				f.Close()
+				if err != nil {
+					return errorResponse(0, err.Error())
+				}
+				root, err := parseDocument(string(b))
+				if err != nil || root.Name != "config" {
+					return errorResponse(18, "Malformed configuration file")
+				}
+				s.saved[fh.Filename] = &node{}
+				s.saved[fh.Filename].Append(root)
+			}
+		}
+	}
+
+	return `<response status="success"><msg>File successfully uploaded</msg></response>`
+}
+
+// commit handles a type=commit request.
+func (s *Server) commit() string {
+	if s.candidate.Equal(s.running) {
+		return `<response status="success" code="19"><msg>There are no changes to commit.</msg></response>`
+	}
+
+	s.running = s.candidate.Copy()
+	s.jobs = append(s.jobs, job{
+		Type:    "Commit",
+		Details: []string{"Configuration committed successfully"},
+	})
+
+	return successResult(fmt.Sprintf("<msg><line>Commit job enqueued with jobid %d</line></msg><job>%d</job>", len(s.jobs), len(s.jobs)))
+}
+
+func (s *Server) showJob(arg string) string {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id < 1 || id > len(s.jobs) {
+		return errorResponse(0, fmt.Sprintf("job %s not found", arg))
+	}
+
+	var buf bytes.Buffer
+	s.writeJob(&buf, id)
+	return successResult(buf.String())
+}
+
+// showJobs answers "show jobs all", listing the newest job first.
+func (s *Server) showJobs() string {
+	var buf bytes.Buffer
+	for id := len(s.jobs); id > 0; id-- {
+		s.writeJob(&buf, id)
+	}
+	return successResult(buf.String())
+}
+
+func (s *Server) writeJob(buf *bytes.Buffer, id int) {
+	j := s.jobs[id-1]
+	fmt.Fprintf(buf, "<job><id>%d</id><type>%s</type><user>%s</user><status>FIN</status><result>OK</result><progress>100</progress><details>", id, j.Type, s.Username)
+	for _, line := range j.Details {
+		(&node{Name: "line", Text: line}).write(buf)
+	}
+	buf.WriteString("</details></job>")
+}
+
+/** Response envelopes **/
+
+func successResult(inner string) string {
+	return fmt.Sprintf(`<response status="success"><result>%s</result></response>`, inner)
+}
+
+func commandSucceeded() string {
+	return `<response status="success" code="20"><msg>command succeeded</msg></response>`
+}
+
+func authFailure() string {
+	return `<response status="error" code="403"><result><msg>Invalid Credential</msg></result></response>`
+}
+
+func errorResponse(code int, msg string) string {
+	var buf bytes.Buffer
+	buf.WriteString(`<response status="error"`)
+	if code != 0 {
+		fmt.Fprintf(&buf, ` code="%d"`, code)
+	}
+	buf.WriteString("><msg><line>")
+	xml.EscapeText(&buf, []byte(msg))
+	buf.WriteString("</line></msg></response>")
+
+	return buf.String()
+}
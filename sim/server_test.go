@@ -0,0 +1,409 @@
+package sim_test
+
+import (
+	"bytes"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inwinstack/pango"
+	"github.com/inwinstack/pango/objs/addr"
+	"github.com/inwinstack/pango/poli/security"
+	"github.com/inwinstack/pango/sim"
+	"github.com/inwinstack/pango/util"
+)
+
+func connect(t *testing.T, s *sim.Server) *pango.Firewall {
+	fw := &pango.Firewall{Client: pango.Client{
+		Hostname: s.Hostname(),
+		Protocol: "http",
+		Username: sim.DefaultUsername,
+		Password: sim.DefaultPassword,
+		Logging:  pango.LogQuiet,
+	}}
+	if err := fw.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %s", err)
+	}
+	return fw
+}
+
+func TestInitialize(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+
+	fw := connect(t, s)
+	if fw.ApiKey != sim.DefaultApiKey {
+		t.Errorf("Unexpected api key: %s", fw.ApiKey)
+	} else if fw.Version.String() != "9.0.0" {
+		t.Errorf("Unexpected version: %s", fw.Version)
+	}
+}
+
+func TestBadCredentials(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+
+	fw := &pango.Firewall{Client: pango.Client{
+		Hostname: s.Hostname(),
+		Protocol: "http",
+		Username: "admin",
+		Password: "wrong",
+		Logging:  pango.LogQuiet,
+	}}
+	if err := fw.Initialize(); err == nil {
+		t.Errorf("Bad credentials were accepted")
+	} else if e, ok := err.(pango.PanosError); !ok || !e.AuthFailure() {
+		t.Errorf("Expected an auth failure, got %#v", err)
+	}
+}
+
+func TestAddressLifecycle(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	one := addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask, Tags: []string{"a"}}
+	two := addr.Entry{Name: "two", Value: "example.com", Type: addr.Fqdn}
+	if err := fw.Objects.Address.Set("", one, two); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+
+	names, err := fw.Objects.Address.GetList("")
+	if err != nil {
+		t.Errorf("Error in get list: %s", err)
+	} else if !reflect.DeepEqual(names, []string{"one", "two"}) {
+		t.Errorf("Unexpected list: %v", names)
+	}
+
+	r, err := fw.Objects.Address.Get("", "one")
+	if err != nil {
+		t.Errorf("Error in get: %s", err)
+	} else if !reflect.DeepEqual(r, one) {
+		t.Errorf("%#v != %#v", r, one)
+	}
+
+	// Show reads the running config, so the object doesn't exist there yet.
+	if _, err = fw.Objects.Address.Show("", "one"); err == nil {
+		t.Errorf("Uncommitted object was in the running config")
+	}
+
+	one.Value = "10.2.2.2"
+	one.Tags = nil
+	if err = fw.Objects.Address.Edit("", one); err != nil {
+		t.Errorf("Error in edit: %s", err)
+	} else if r, _ = fw.Objects.Address.Get("", "one"); !reflect.DeepEqual(r, one) {
+		t.Errorf("%#v != %#v", r, one)
+	}
+
+	if err = fw.Objects.Address.Delete("", "two"); err != nil {
+		t.Errorf("Error in delete: %s", err)
+	}
+	if _, err = fw.Objects.Address.Get("", "two"); err == nil {
+		t.Errorf("Deleted object still exists")
+	} else if e, ok := err.(pango.PanosError); !ok || !e.ObjectNotFound() {
+		t.Errorf("Expected object not found, got %#v", err)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+	fw.JobPollInterval = time.Millisecond
+
+	if job, err := fw.Commit("", nil, true, true, false, true); err != nil || job != 0 {
+		t.Errorf("Expected no commit to be needed, got %d %v", job, err)
+	}
+
+	e := addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}
+	if err := fw.Objects.Address.Set("", e); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+
+	job, err := fw.Commit("", nil, true, true, false, true)
+	if err != nil {
+		t.Errorf("Error in commit: %s", err)
+	} else if job != 1 {
+		t.Errorf("Expected job 1, got %d", job)
+	}
+
+	if r, err := fw.Objects.Address.Show("", "one"); err != nil {
+		t.Errorf("Error in show: %s", err)
+	} else if !reflect.DeepEqual(r, e) {
+		t.Errorf("%#v != %#v", r, e)
+	}
+	if s.CandidateConfig() != s.RunningConfig() {
+		t.Errorf("Running config is not the candidate config after commit")
+	}
+
+	// Revert discards uncommitted changes.
+	if err = fw.Objects.Address.Delete("", "one"); err != nil {
+		t.Errorf("Error in delete: %s", err)
+	} else if err = fw.RevertToRunningConfig(); err != nil {
+		t.Errorf("Error in revert: %s", err)
+	} else if _, err = fw.Objects.Address.Get("", "one"); err != nil {
+		t.Errorf("Object not restored by revert: %s", err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	rules := []security.Entry{
+		{Name: "a", SourceZones: []string{"any"}},
+		{Name: "b", SourceZones: []string{"any"}},
+		{Name: "c", SourceZones: []string{"any"}},
+	}
+	if err := fw.Policies.Security.Set("", rules...); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+
+	if err := fw.Policies.Security.MoveGroup("", util.MoveTop, "", rules[2]); err != nil {
+		t.Errorf("Error moving to top: %s", err)
+	} else if names, _ := fw.Policies.Security.GetList(""); !reflect.DeepEqual(names, []string{"c", "a", "b"}) {
+		t.Errorf("Unexpected order: %v", names)
+	}
+
+	if err := fw.Policies.Security.MoveGroup("", util.MoveTop, "", rules[2]); err != nil {
+		t.Errorf("Moving an entry already at the top failed: %s", err)
+	}
+
+	if err := fw.Policies.Security.MoveGroup("", util.MoveDirectlyAfter, "b", rules[2]); err != nil {
+		t.Errorf("Error moving after: %s", err)
+	} else if names, _ := fw.Policies.Security.GetList(""); !reflect.DeepEqual(names, []string{"a", "b", "c"}) {
+		t.Errorf("Unexpected order: %v", names)
+	}
+
+	// A failed move leaves the entry where it was, still movable.
+	path := "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/rulebase/security/rules/entry[@name='c']"
+	if _, err := fw.Move(path, "after", "missing", nil, nil); err == nil {
+		t.Errorf("Expected an error moving after a missing entry")
+	} else if names, _ := fw.Policies.Security.GetList(""); !reflect.DeepEqual(names, []string{"a", "b", "c"}) {
+		t.Errorf("Unexpected order after failed move: %v", names)
+	}
+	if _, err := fw.Move(path, "top", "", nil, nil); err != nil {
+		t.Errorf("Error moving after a failed move: %s", err)
+	} else if names, _ := fw.Policies.Security.GetList(""); !reflect.DeepEqual(names, []string{"c", "a", "b"}) {
+		t.Errorf("Unexpected order: %v", names)
+	}
+}
+
+func TestRename(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	if err := fw.Objects.Address.Set("", addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}, addr.Entry{Name: "two", Value: "10.2.2.2", Type: addr.IpNetmask}); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+
+	rename := func(from, to string) error {
+		data := url.Values{}
+		data.Set("type", "config")
+		data.Set("action", "rename")
+		data.Set("xpath", "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/address/entry[@name='"+from+"']")
+		data.Set("newname", to)
+		_, err := fw.Communicate(data, nil)
+		return err
+	}
+
+	if err := rename("one", "two"); err == nil {
+		t.Errorf("Rename to an existing name succeeded")
+	} else if e, ok := err.(pango.PanosError); !ok || !e.ObjectExists() {
+		t.Errorf("Expected object exists, got %#v", err)
+	}
+
+	if err := rename("one", "three"); err != nil {
+		t.Errorf("Error in rename: %s", err)
+	} else if names, _ := fw.Objects.Address.GetList(""); !reflect.DeepEqual(names, []string{"three", "two"}) {
+		t.Errorf("Unexpected list: %v", names)
+	}
+}
+
+func TestEditValidity(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	_, err := fw.Edit("/config/shared/address/entry[@name='one']", "<entry name='two'/>", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "edit breaks config validity") {
+		t.Errorf("Expected a validity error, got %v", err)
+	}
+}
+
+func TestPanorama(t *testing.T) {
+	s := sim.NewPanorama("8.1.0")
+	s.Start()
+	defer s.Close()
+
+	con, err := pango.Connect(pango.Client{
+		Hostname: s.Hostname(),
+		Protocol: "http",
+		Username: sim.DefaultUsername,
+		Password: sim.DefaultPassword,
+		Logging:  pango.LogQuiet,
+	})
+	if err != nil {
+		t.Fatalf("Connect failed: %s", err)
+	}
+
+	pano, ok := con.(*pango.Panorama)
+	if !ok {
+		t.Fatalf("Expected a Panorama, got %T", con)
+	}
+
+	e := addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}
+	if err = pano.Objects.Address.Set("dg1", e); err != nil {
+		t.Errorf("Error in set: %s", err)
+	} else if r, err := pano.Objects.Address.Get("dg1", "one"); err != nil || !reflect.DeepEqual(r, e) {
+		t.Errorf("Get returned %#v, %v", r, err)
+	}
+}
+
+func TestMultiConfig(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	b := fw.NewBatch()
+	tx := fw.WithBatch(b)
+	tx.Objects.Address.Set("", addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask})
+	tx.Objects.Address.Set("", addr.Entry{Name: "two", Value: "10.2.2.2", Type: addr.IpNetmask})
+	if names, _ := fw.Objects.Address.GetList(""); len(names) != 0 {
+		t.Fatalf("Batched changes were applied early: %v", names)
+	}
+
+	results, err := b.Send()
+	if err != nil {
+		t.Fatalf("Error in send: %s", err)
+	} else if len(results) != 2 || results[0].Status != "success" || results[1].Status != "success" {
+		t.Errorf("Unexpected results: %#v", results)
+	}
+	if names, _ := fw.Objects.Address.GetList(""); !reflect.DeepEqual(names, []string{"one", "two"}) {
+		t.Errorf("Unexpected list: %v", names)
+	}
+
+	// A failing operation means none of the operations are applied.
+	before := s.CandidateConfig()
+	tx.Objects.Address.Delete("", "one")
+	b.Move("/config/shared/address/entry[@name='missing']", "top", "")
+	results, err = b.Send()
+	if err == nil {
+		t.Errorf("Expected an error")
+	} else if len(results) != 2 || results[0].Error != nil || results[1].Error == nil {
+		t.Errorf("Unexpected results: %#v", results)
+	}
+	if s.CandidateConfig() != before {
+		t.Errorf("Candidate config changed by a failed batch")
+	}
+}
+
+func TestDiffVsys(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	if d, err := fw.DiffVsys(""); err != nil {
+		t.Fatalf("Error in diff: %s", err)
+	} else if len(d) != 0 {
+		t.Errorf("Unexpected changes:\n%s", d)
+	}
+
+	if err := fw.Objects.Address.Set("vsys1", addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+
+	d, err := fw.DiffVsys("vsys1")
+	if err != nil {
+		t.Fatalf("Error in diff: %s", err)
+	}
+	xp := "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/address"
+	if ch, ok := d[xp]; len(d) != 1 || !ok || ch.Action != pango.DiffAdded {
+		t.Errorf("Unexpected changes:\n%s", d)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+
+	e := addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}
+	if err := fw.Objects.Address.Set("", e); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+	if err := fw.Snapshots.Save("snap.xml"); err != nil {
+		t.Fatalf("Error in save: %s", err)
+	}
+	if list, err := fw.Snapshots.List(); err != nil || !reflect.DeepEqual(list, []string{"snap.xml"}) {
+		t.Errorf("Unexpected list: %v %v", list, err)
+	}
+
+	var buf bytes.Buffer
+	if err := fw.Snapshots.Export("snap.xml", &buf); err != nil {
+		t.Fatalf("Error in export: %s", err)
+	} else if !strings.Contains(buf.String(), `<entry name="one">`) {
+		t.Errorf("Exported config is missing the address: %s", buf.String())
+	}
+
+	if err := fw.Snapshots.Load("running-config.xml"); err != nil {
+		t.Fatalf("Error in load: %s", err)
+	} else if _, err = fw.Objects.Address.Get("", "one"); err == nil {
+		t.Errorf("Address still exists after loading the running config")
+	}
+
+	if err := fw.Snapshots.Import("copy.xml", &buf); err != nil {
+		t.Fatalf("Error in import: %s", err)
+	} else if err = fw.Snapshots.Load("copy.xml"); err != nil {
+		t.Fatalf("Error loading imported config: %s", err)
+	} else if r, err := fw.Objects.Address.Get("", "one"); err != nil || !reflect.DeepEqual(r, e) {
+		t.Errorf("Unexpected address after import: %#v %v", r, err)
+	}
+
+	buf.Reset()
+	if err := fw.Snapshots.Export("", &buf); err != nil {
+		t.Fatalf("Error exporting running config: %s", err)
+	} else if buf.String() != s.RunningConfig() {
+		t.Errorf("Exported running config mismatch: %s", buf.String())
+	}
+}
+
+func TestJobs(t *testing.T) {
+	s := sim.NewFirewall("9.0.0")
+	s.Start()
+	defer s.Close()
+	fw := connect(t, s)
+	fw.JobPollInterval = time.Millisecond
+
+	if err := fw.Objects.Address.Set("", addr.Entry{Name: "one", Value: "10.1.1.1", Type: addr.IpNetmask}); err != nil {
+		t.Fatalf("Error in set: %s", err)
+	}
+	if _, err := fw.Commit("", nil, true, true, false, true); err != nil {
+		t.Fatalf("Error in commit: %s", err)
+	}
+
+	list, err := fw.Jobs.All()
+	if err != nil {
+		t.Fatalf("Error listing jobs: %s", err)
+	} else if len(list) != 1 || list[0].Type != "Commit" || list[0].User != sim.DefaultUsername || !list[0].Done() {
+		t.Errorf("Unexpected jobs: %#v", list)
+	}
+
+	if err = fw.Jobs.WaitForActive("Commit"); err != nil {
+		t.Errorf("Error waiting for active jobs: %s", err)
+	}
+}
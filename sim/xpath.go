@@ -0,0 +1,212 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// step is a single location step of an xpath, such as "entry[@name='foo']".
+//
+// Only the subset of xpath that pango generates is supported: element names,
+// a "@name" attribute step, and predicates that are either an "or" of
+// "@name='...'" or an "or" of "text()='...'".
+type step struct {
+	Tag   string
+	Attr  string
+	Names []string
+	Texts []string
+}
+
+// Matches checks if the given node is selected by this step.
+func (s step) Matches(n *node) bool {
+	if n.Name != s.Tag {
+		return false
+	}
+
+	if len(s.Names) > 0 {
+		name := n.Attr("name")
+		for _, v := range s.Names {
+			if v == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(s.Texts) > 0 {
+		for _, v := range s.Texts {
+			if v == n.Text {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// Create returns a new node that this step would select, if this step
+// selects a single node.
+func (s step) Create() (*node, error) {
+	switch {
+	case s.Attr != "":
+		return nil, fmt.Errorf("cannot create attribute %q", s.Attr)
+	case len(s.Names) > 1 || len(s.Texts) > 1:
+		return nil, fmt.Errorf("cannot create multiple %q nodes", s.Tag)
+	}
+
+	n := &node{Name: s.Tag}
+	if len(s.Names) == 1 {
+		n.SetAttr("name", s.Names[0])
+	} else if len(s.Texts) == 1 {
+		n.Text = s.Texts[0]
+	}
+	return n, nil
+}
+
+// parseXpath splits the given absolute xpath into steps.
+func parseXpath(xp string) ([]step, error) {
+	if !strings.HasPrefix(xp, "/") {
+		return nil, fmt.Errorf("xpath must be absolute")
+	}
+
+	parts := make([]string, 0, 10)
+	var quote rune
+	depth := 0
+	start := 1
+	for i, r := range xp {
+		switch {
+		case i == 0:
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '/' && depth == 0:
+			parts = append(parts, xp[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, xp[start:])
+
+	ans := make([]step, 0, len(parts))
+	for i, p := range parts {
+		s, err := parseStep(p)
+		if err != nil {
+			return nil, err
+		} else if s.Attr != "" && i != len(parts)-1 {
+			return nil, fmt.Errorf("attribute step must be last")
+		}
+		ans = append(ans, s)
+	}
+
+	return ans, nil
+}
+
+func parseStep(p string) (step, error) {
+	var s step
+
+	if p == "" {
+		return s, fmt.Errorf("empty xpath step")
+	} else if strings.HasPrefix(p, "@") {
+		s.Attr = p[1:]
+		return s, nil
+	}
+
+	idx := strings.Index(p, "[")
+	if idx == -1 {
+		s.Tag = p
+		return s, nil
+	} else if !strings.HasSuffix(p, "]") {
+		return s, fmt.Errorf("unterminated predicate in %q", p)
+	}
+	s.Tag = p[:idx]
+
+	for _, cond := range strings.Split(p[idx+1:len(p)-1], " or ") {
+		cond = strings.TrimSpace(cond)
+		eq := strings.Index(cond, "=")
+		if eq == -1 {
+			return s, fmt.Errorf("unsupported predicate %q", cond)
+		}
+		key, val := strings.TrimSpace(cond[:eq]), strings.TrimSpace(cond[eq+1:])
+		if len(val) < 2 || (val[0] != '\'' && val[0] != '"') || val[len(val)-1] != val[0] {
+			return s, fmt.Errorf("unquoted value in predicate %q", cond)
+		}
+		val = val[1 : len(val)-1]
+
+		switch key {
+		case "@name":
+			s.Names = append(s.Names, val)
+		case "text()":
+			s.Texts = append(s.Texts, val)
+		default:
+			return s, fmt.Errorf("unsupported predicate %q", cond)
+		}
+	}
+
+	if len(s.Names) > 0 && len(s.Texts) > 0 {
+		return s, fmt.Errorf("mixed predicates in %q", p)
+	}
+
+	return s, nil
+}
+
+// find returns all nodes beneath root selected by the given steps.  If the
+// last step is an attribute step, the nodes that have that attribute are
+// returned.
+func find(root *node, steps []step) []*node {
+	cur := []*node{root}
+	for _, s := range steps {
+		next := make([]*node, 0, len(cur))
+		for _, n := range cur {
+			if s.Attr != "" {
+				if n.Attr(s.Attr) != "" {
+					next = append(next, n)
+				}
+				continue
+			}
+			for _, c := range n.Children {
+				if s.Matches(c) {
+					next = append(next, c)
+				}
+			}
+		}
+		cur = next
+	}
+
+	return cur
+}
+
+// findOrCreate returns the single node beneath root selected by the given
+// steps, creating it and any missing parents if it does not exist.
+func findOrCreate(root *node, steps []step) (*node, error) {
+	cur := root
+	for _, s := range steps {
+		var next *node
+		for _, c := range cur.Children {
+			if s.Matches(c) {
+				if next != nil {
+					return nil, fmt.Errorf("xpath matches multiple %q nodes", s.Tag)
+				}
+				next = c
+			}
+		}
+
+		if next == nil {
+			n, err := s.Create()
+			if err != nil {
+				return nil, err
+			}
+			cur.Append(n)
+			next = n
+		}
+		cur = next
+	}
+
+	return cur, nil
+}
@@ -0,0 +1,166 @@
+// Package snapshot is the client.Snapshots namespace.
+//
+// It manages named config files saved on the PAN-OS device, and the export
+// and import of full configuration files.
+package snapshot
+
+import (
+    "encoding/xml"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "net/url"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Snapshot is the client.Snapshots namespace.
+type Snapshot struct {
+    con util.XapiClient
+}
+
+// Initialize is invoked on client.Initialize().
+func (c *Snapshot) Initialize(i util.XapiClient) {
+    c.con = i
+}
+
+// List returns the names of the config files saved on the device.
+func (c *Snapshot) List() ([]string, error) {
+    c.con.LogOp("(op) listing saved configs")
+
+    data := url.Values{}
+    data.Set("type", "op")
+    data.Set("action", "complete")
+    data.Set("xpath", "/operational/show/config/saved")
+
+    ans := completions{}
+    if _, err := c.con.Communicate(data, &ans); err != nil {
+        return nil, err
+    }
+
+    list := make([]string, 0, len(ans.Entries))
+    for _, v := range ans.Entries {
+        list = append(list, v.Value)
+    }
+
+    return list, nil
+}
+
+// Save saves the candidate config to the given config file name.
+func (c *Snapshot) Save(name string) error {
+    type req struct {
+        XMLName xml.Name `xml:"save"`
+        Name string `xml:"config>to"`
+    }
+
+    c.con.LogOp("(op) save config to %q", name)
+    _, err := c.con.Op(req{Name: name}, "", nil, nil)
+    return err
+}
+
+// Load replaces the candidate config with the given saved config file.
+//
+// Specify "running-config.xml" to revert the candidate config back to
+// the running config.
+func (c *Snapshot) Load(name string) error {
+    type req struct {
+        XMLName xml.Name `xml:"load"`
+        Name string `xml:"config>from"`
+    }
+
+    c.con.LogOp("(op) load config from %q", name)
+    _, err := c.con.Op(req{Name: name}, "", nil, nil)
+    return err
+}
+
+// Show returns the contents of the given saved config file.
+func (c *Snapshot) Show(name string) (string, error) {
+    type req struct {
+        XMLName xml.Name `xml:"show"`
+        Name string `xml:"config>saved"`
+    }
+
+    c.con.LogOp("(op) show config saved %q", name)
+    ans := util.RawXml{}
+    if _, err := c.con.Op(req{Name: name}, "", nil, &rawResult{Result: &ans}); err != nil {
+        return "", err
+    }
+
+    return ans.Text, nil
+}
+
+// Delete removes the given saved config file.
+func (c *Snapshot) Delete(name string) error {
+    type req struct {
+        XMLName xml.Name `xml:"delete"`
+        Name string `xml:"config>saved"`
+    }
+
+    c.con.LogOp("(op) delete config saved %q", name)
+    _, err := c.con.Op(req{Name: name}, "", nil, nil)
+    return err
+}
+
+// Export writes a config file to the given writer.
+//
+// If name is an empty string, then the running config is exported, otherwise
+// the given saved config file is exported.
+func (c *Snapshot) Export(name string, w io.Writer) error {
+    var content string
+
+    if name == "" {
+        c.con.LogOp("(export) running config")
+
+        data := url.Values{}
+        data.Set("type", "export")
+        data.Set("category", "configuration")
+
+        body, err := c.con.Communicate(data, nil)
+        if err != nil {
+            return err
+        }
+        content = string(body)
+    } else {
+        var err error
+        if content, err = c.Show(name); err != nil {
+            return err
+        }
+    }
+
+    _, err := io.WriteString(w, content)
+    return err
+}
+
+// Import uploads the config file read from the given reader, saving it on
+// the device as the given config file name.  Use Load() to make the imported
+// config file the candidate config.
+func (c *Snapshot) Import(name string, r io.Reader) error {
+    if name == "" {
+        return fmt.Errorf("name must be specified")
+    }
+
+    b, err := ioutil.ReadAll(r)
+    if err != nil {
+        return err
+    }
+
+    c.con.LogOp("(import) configuration %q", name)
+    _, err = c.con.Import("configuration", string(b), name, "file", nil, nil)
+    return err
+}
+
+/** Internal structs **/
+
+type completions struct {
+    Entries []completion `xml:"completions>completion"`
+}
+
+type completion struct {
+    Value string `xml:"value,attr"`
+}
+
+type rawResult struct {
+    XMLName xml.Name `xml:"response"`
+    Result *util.RawXml `xml:"result"`
+}
@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+    "bytes"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestList(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.Resp = append(mc.Resp, testdata.Response{[]byte(`<response status="success"><completions><completion value="a.xml"/><completion value="b.xml"/></completions></response>`), nil})
+    c := &Snapshot{}
+    c.Initialize(mc)
+
+    list, err := c.List()
+    if err != nil {
+        t.Fatalf("Error in list: %s", err)
+    }
+    if !reflect.DeepEqual(list, []string{"a.xml", "b.xml"}) {
+        t.Errorf("Unexpected list: %v", list)
+    }
+    if mc.Data.Get("action") != "complete" || mc.Data.Get("xpath") != "/operational/show/config/saved" {
+        t.Errorf("Unexpected request: %v", mc.Data)
+    }
+}
+
+func TestSaveLoadDelete(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp("")
+    c := &Snapshot{}
+    c.Initialize(mc)
+
+    testCases := []struct{
+        desc string
+        fn func(string) error
+        elm string
+    }{
+        {"save", c.Save, "<save><config><to>snap.xml</to></config></save>"},
+        {"load", c.Load, "<load><config><from>snap.xml</from></config></load>"},
+        {"delete", c.Delete, "<delete><config><saved>snap.xml</saved></config></delete>"},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            if err := tc.fn("snap.xml"); err != nil {
+                t.Errorf("Error: %s", err)
+            } else if mc.Elm != tc.elm {
+                t.Errorf("Expected %q, got %q", tc.elm, mc.Elm)
+            }
+        })
+    }
+}
+
+func TestExport(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp(`<config version="9.0.0"><shared/></config>`)
+    c := &Snapshot{}
+    c.Initialize(mc)
+
+    var buf bytes.Buffer
+    if err := c.Export("snap.xml", &buf); err != nil {
+        t.Fatalf("Error in export: %s", err)
+    }
+    if buf.String() != `<config version="9.0.0"><shared/></config>` {
+        t.Errorf("Unexpected export: %s", buf.String())
+    }
+    if mc.Elm != "<show><config><saved>snap.xml</saved></config></show>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+
+    mc.Resp = []testdata.Response{{[]byte(`<config version="9.0.0"/>`), nil}}
+    buf.Reset()
+    if err := c.Export("", &buf); err != nil {
+        t.Fatalf("Error in export: %s", err)
+    }
+    if buf.String() != `<config version="9.0.0"/>` {
+        t.Errorf("Unexpected export: %s", buf.String())
+    } else if mc.Data.Get("type") != "export" || mc.Data.Get("category") != "configuration" {
+        t.Errorf("Unexpected request: %v", mc.Data)
+    }
+}
+
+func TestImport(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp("")
+    c := &Snapshot{}
+    c.Initialize(mc)
+
+    if err := c.Import("", strings.NewReader("<config/>")); err == nil {
+        t.Errorf("Import without a name succeeded")
+    }
+
+    if err := c.Import("snap.xml", strings.NewReader("<config/>")); err != nil {
+        t.Fatalf("Error in import: %s", err)
+    }
+    if mc.Function != "import" || mc.Data.Get("category") != "configuration" {
+        t.Errorf("Unexpected request: %s %v", mc.Function, mc.Data)
+    } else if mc.Filename != "snap.xml" || mc.Elm != "<config/>" {
+        t.Errorf("Unexpected file: %s %s", mc.Filename, mc.Elm)
+    }
+}
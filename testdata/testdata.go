@@ -1,8 +1,10 @@
 package testdata
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
+	"net/url"
 
 	"github.com/inwinstack/pango/util"
 	"github.com/inwinstack/pango/version"
@@ -32,6 +34,8 @@ type MockClient struct {
 	TemplateStack string
 	Vsys          string
 	Extras        interface{}
+	Data          url.Values
+	Filename      string
 }
 
 func (c *MockClient) String() string                                              { return "mock" }
@@ -116,6 +120,89 @@ func (c *MockClient) Uid(cmd interface{}, vsys string, extras, resp interface{})
 	return c.finalize(resp)
 }
 
+func (c *MockClient) Communicate(data url.Values, ans interface{}) ([]byte, error) {
+	c.Function = "communicate"
+	c.Data = data
+
+	return c.finalize(ans)
+}
+
+func (c *MockClient) Import(cat, content, filename, fp string, extras map[string]string, ans interface{}) ([]byte, error) {
+	c.Function = "import"
+	c.Data = url.Values{}
+	c.Data.Set("category", cat)
+	for k := range extras {
+		c.Data.Set(k, extras[k])
+	}
+	c.Elm = content
+	c.Filename = filename
+
+	return c.finalize(ans)
+}
+
+func (c *MockClient) OpContext(ctx context.Context, req interface{}, vsys string, extras interface{}, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Op(req, vsys, extras, ans)
+}
+
+func (c *MockClient) ShowContext(ctx context.Context, path interface{}, extras interface{}, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Show(path, extras, ans)
+}
+
+func (c *MockClient) GetContext(ctx context.Context, path interface{}, extras interface{}, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Get(path, extras, ans)
+}
+
+func (c *MockClient) DeleteContext(ctx context.Context, path interface{}, extras interface{}, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Delete(path, extras, ans)
+}
+
+func (c *MockClient) SetContext(ctx context.Context, path, elm, extras, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Set(path, elm, extras, ans)
+}
+
+func (c *MockClient) EditContext(ctx context.Context, path, elm, extras, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Edit(path, elm, extras, ans)
+}
+
+func (c *MockClient) MoveContext(ctx context.Context, path interface{}, where, dst string, extras, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Move(path, where, dst, extras, ans)
+}
+
+func (c *MockClient) UidContext(ctx context.Context, cmd interface{}, vsys string, extras, resp interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Uid(cmd, vsys, extras, resp)
+}
+
+func (c *MockClient) CommunicateContext(ctx context.Context, data url.Values, ans interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Communicate(data, ans)
+}
+
 func (c *MockClient) EntryListUsing(fn util.Retriever, path []string) ([]string, error) {
 	c.Path = util.AsXpath(path)
 	return nil, nil
@@ -179,6 +266,13 @@ func (c *MockClient) WaitForJob(a uint, resp interface{}) error {
 	return err
 }
 
+func (c *MockClient) WaitForJobContext(ctx context.Context, a uint, resp interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.WaitForJob(a, resp)
+}
+
 func (c *MockClient) AddResp(val string) {
 	c.Resp = append(c.Resp, Response{
 		[]byte(fmt.Sprintf("<response><result>%s</result></response>", val)), nil,
@@ -195,6 +289,8 @@ func (c *MockClient) Reset() {
 	c.TemplateStack = ""
 	c.Vsys = ""
 	c.Extras = nil
+	c.Data = nil
+	c.Filename = ""
 }
 
 const (
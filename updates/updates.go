@@ -0,0 +1,365 @@
+// Package updates is the client.Updates namespace.
+//
+// It manages PAN-OS software versions as well as dynamic content updates:
+// applications and threats, antivirus, WildFire, and the URL database.
+//
+// Downloads and installs submit a job to PAN-OS, and take a sync param which
+// should be true if you want the function to block until the job completes.
+package updates
+
+import (
+    "context"
+    "encoding/xml"
+    "fmt"
+    "time"
+
+    "github.com/inwinstack/pango/util"
+)
+
+
+// Content types that can be checked, downloaded, and installed.
+const (
+    Content = "content"
+    Antivirus = "anti-virus"
+    Wildfire = "wildfire"
+)
+
+// Defaults for waiting on a device to come back after a reboot.
+const (
+    DefaultRebootPollInterval = 10 * time.Second
+    DefaultRebootTimeout = 20 * time.Minute
+)
+
+// Updates is the client.Updates namespace.
+type Updates struct {
+    // RebootPollInterval is how often to check if a rebooting device is
+    // reachable again.  If unset, DefaultRebootPollInterval is used.
+    RebootPollInterval time.Duration
+
+    // RebootTimeout is how long to wait for a rebooting device to become
+    // reachable again.  If unset, DefaultRebootTimeout is used.
+    RebootTimeout time.Duration
+
+    con util.XapiClient
+}
+
+// Initialize is invoked on client.Initialize().
+func (c *Updates) Initialize(i util.XapiClient) {
+    c.con = i
+}
+
+// SoftwareVersion is a PAN-OS software version available to the device.
+type SoftwareVersion struct {
+    Version string
+    Filename string
+    Size string
+    ReleasedOn string
+    Downloaded bool
+    Current bool
+    Latest bool
+    Uploaded bool
+}
+
+// ContentVersion is a content update version available to the device.
+type ContentVersion struct {
+    Version string
+    AppVersion string
+    Filename string
+    Size string
+    ReleasedOn string
+    Downloaded bool
+    Current bool
+    Previous bool
+    Installing bool
+}
+
+// CheckSoftware contacts the update server, returning the software versions
+// available to the device.
+func (c *Updates) CheckSoftware() ([]SoftwareVersion, error) {
+    c.con.LogOp("(op) request system software check")
+    return c.software(softwareReq{Check: new(string)})
+}
+
+// SoftwareInfo returns the software versions known to the device without
+// contacting the update server.
+func (c *Updates) SoftwareInfo() ([]SoftwareVersion, error) {
+    c.con.LogOp("(op) request system software info")
+    return c.software(softwareReq{Info: new(string)})
+}
+
+// DownloadSoftware downloads the given software version.
+func (c *Updates) DownloadSoftware(version string, sync bool) (uint, error) {
+    c.con.LogOp("(op) request system software download version %q", version)
+    return c.job(softwareReq{Download: &version}, sync)
+}
+
+// InstallSoftware installs the given software version, which must already be
+// downloaded.  The device needs to be rebooted afterwards for the new version
+// to take effect.
+func (c *Updates) InstallSoftware(version string, sync bool) (uint, error) {
+    c.con.LogOp("(op) request system software install version %q", version)
+    return c.job(softwareReq{Install: &version}, sync)
+}
+
+// CheckContent contacts the update server, returning the versions available
+// for the given content type.
+func (c *Updates) CheckContent(kind string) ([]ContentVersion, error) {
+    c.con.LogOp("(op) request %s upgrade check", kind)
+    return c.content(kind, upgradeReq{Check: new(string)})
+}
+
+// ContentInfo returns the versions known to the device for the given
+// content type without contacting the update server.
+func (c *Updates) ContentInfo(kind string) ([]ContentVersion, error) {
+    c.con.LogOp("(op) request %s upgrade info", kind)
+    return c.content(kind, upgradeReq{Info: new(string)})
+}
+
+// DownloadContent downloads the latest version of the given content type.
+func (c *Updates) DownloadContent(kind string, sync bool) (uint, error) {
+    c.con.LogOp("(op) request %s upgrade download latest", kind)
+    return c.job(contentReq(kind, upgradeReq{Download: new(string)}), sync)
+}
+
+// InstallContent installs the given version of the given content type, which
+// must already be downloaded.
+//
+// If version is an empty string, then the latest version is installed.
+func (c *Updates) InstallContent(kind, version string, sync bool) (uint, error) {
+    if version == "" {
+        version = "latest"
+    }
+
+    c.con.LogOp("(op) request %s upgrade install version %q", kind, version)
+    return c.job(contentReq(kind, upgradeReq{Install: &version}), sync)
+}
+
+// UpgradeUrlDatabase downloads and installs the latest BrightCloud URL
+// filtering database.  PAN-DB URL filtering is cloud based and does not need
+// to be upgraded.
+func (c *Updates) UpgradeUrlDatabase(sync bool) (uint, error) {
+    type req struct {
+        XMLName xml.Name `xml:"request"`
+        Cmd string `xml:"url-filtering>upgrade>brightcloud"`
+    }
+
+    c.con.LogOp("(op) request url-filtering upgrade brightcloud")
+    return c.job(req{}, sync)
+}
+
+// Reboot reboots the device.
+//
+// If wait is true, then this function blocks until the device is reachable
+// again, which can take several minutes; see WaitForReboot().
+func (c *Updates) Reboot(wait bool) error {
+    type req struct {
+        XMLName xml.Name `xml:"request"`
+        Cmd string `xml:"restart>system"`
+    }
+
+    c.con.LogOp("(op) request restart system")
+    if _, err := c.con.Op(req{}, "", nil, nil); err != nil {
+        return err
+    }
+
+    if !wait {
+        return nil
+    }
+
+    return c.WaitForReboot()
+}
+
+// WaitForReboot waits for a device that was just told to reboot to go down
+// and come back up again, as determined by "show system info" failing and
+// then succeeding again.
+//
+// The device is polled every RebootPollInterval, and an error is returned if
+// the device is not reachable again within RebootTimeout.  The wait is bound
+// to the client's context, so it stops early if that context is done.
+func (c *Updates) WaitForReboot() error {
+    return c.WaitForRebootContext(c.context())
+}
+
+// WaitForRebootContext is WaitForReboot, but bound to the given context.
+func (c *Updates) WaitForRebootContext(ctx context.Context) error {
+    interval := c.RebootPollInterval
+    if interval <= 0 {
+        interval = DefaultRebootPollInterval
+    }
+    timeout := c.RebootTimeout
+    if timeout <= 0 {
+        timeout = DefaultRebootTimeout
+    }
+    end := time.Now().Add(timeout)
+
+    c.con.LogOp("(op) waiting for reboot")
+    down := false
+    for {
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        case <-time.After(interval):
+        }
+
+        up := c.reachable(ctx)
+        if err := ctx.Err(); err != nil {
+            return err
+        }
+        if !down && !up {
+            c.con.LogOp("(op) device is down, waiting for it to come back")
+            down = true
+        } else if down && up {
+            c.con.LogOp("(op) device is back up")
+            return nil
+        }
+
+        if time.Now().After(end) {
+            if down {
+                return fmt.Errorf("Device did not come back within %s", timeout)
+            }
+            return fmt.Errorf("Device did not go down within %s", timeout)
+        }
+    }
+}
+
+/** Internal functions **/
+
+// context returns the context the client's API calls are bound to.
+func (c *Updates) context() context.Context {
+    if x, ok := c.con.(interface{ Context() context.Context }); ok {
+        return x.Context()
+    }
+
+    return context.Background()
+}
+
+func (c *Updates) reachable(ctx context.Context) bool {
+    type req struct {
+        XMLName xml.Name `xml:"show"`
+        Cmd string `xml:"system>info"`
+    }
+
+    _, err := c.con.OpContext(ctx, req{}, "", nil, nil)
+    return err == nil
+}
+
+func (c *Updates) job(req interface{}, sync bool) (uint, error) {
+    ans := util.JobResponse{}
+    if _, err := c.con.Op(req, "", nil, &ans); err != nil {
+        return 0, err
+    }
+
+    if !sync || ans.Id == 0 {
+        return ans.Id, nil
+    }
+
+    return ans.Id, c.con.WaitForJob(ans.Id, nil)
+}
+
+func (c *Updates) software(req softwareReq) ([]SoftwareVersion, error) {
+    ans := softwareResp{}
+    if _, err := c.con.Op(req, "", nil, &ans); err != nil {
+        return nil, err
+    }
+
+    list := make([]SoftwareVersion, 0, len(ans.Entries))
+    for _, e := range ans.Entries {
+        list = append(list, SoftwareVersion{
+            Version: e.Version,
+            Filename: e.Filename,
+            Size: e.Size,
+            ReleasedOn: e.ReleasedOn,
+            Downloaded: util.AsBool(e.Downloaded),
+            Current: util.AsBool(e.Current),
+            Latest: util.AsBool(e.Latest),
+            Uploaded: util.AsBool(e.Uploaded),
+        })
+    }
+
+    return list, nil
+}
+
+func (c *Updates) content(kind string, req upgradeReq) ([]ContentVersion, error) {
+    ans := contentResp{}
+    if _, err := c.con.Op(contentReq(kind, req), "", nil, &ans); err != nil {
+        return nil, err
+    }
+
+    list := make([]ContentVersion, 0, len(ans.Entries))
+    for _, e := range ans.Entries {
+        list = append(list, ContentVersion{
+            Version: e.Version,
+            AppVersion: e.AppVersion,
+            Filename: e.Filename,
+            Size: e.Size,
+            ReleasedOn: e.ReleasedOn,
+            Downloaded: util.AsBool(e.Downloaded),
+            Current: util.AsBool(e.Current),
+            Previous: util.AsBool(e.Previous),
+            Installing: util.AsBool(e.Installing),
+        })
+    }
+
+    return list, nil
+}
+
+func contentReq(kind string, cmd upgradeReq) interface{} {
+    cmd.XMLName = xml.Name{Local: kind}
+    return request{Cmd: cmd}
+}
+
+/** Internal structs **/
+
+type softwareReq struct {
+    XMLName xml.Name `xml:"request"`
+    Check *string `xml:"system>software>check"`
+    Info *string `xml:"system>software>info"`
+    Download *string `xml:"system>software>download>version"`
+    Install *string `xml:"system>software>install>version"`
+}
+
+type request struct {
+    XMLName xml.Name `xml:"request"`
+    Cmd upgradeReq
+}
+
+type upgradeReq struct {
+    XMLName xml.Name
+    Check *string `xml:"upgrade>check"`
+    Info *string `xml:"upgrade>info"`
+    Download *string `xml:"upgrade>download>latest"`
+    Install *string `xml:"upgrade>install>version"`
+}
+
+type softwareResp struct {
+    XMLName xml.Name `xml:"response"`
+    Entries []softwareEntry `xml:"result>sw-updates>versions>entry"`
+}
+
+type softwareEntry struct {
+    Version string `xml:"version"`
+    Filename string `xml:"filename"`
+    Size string `xml:"size"`
+    ReleasedOn string `xml:"released-on"`
+    Downloaded string `xml:"downloaded"`
+    Current string `xml:"current"`
+    Latest string `xml:"latest"`
+    Uploaded string `xml:"uploaded"`
+}
+
+type contentResp struct {
+    XMLName xml.Name `xml:"response"`
+    Entries []contentEntry `xml:"result>content-updates>entry"`
+}
+
+type contentEntry struct {
+    Version string `xml:"version"`
+    AppVersion string `xml:"app-version"`
+    Filename string `xml:"filename"`
+    Size string `xml:"size"`
+    ReleasedOn string `xml:"released-on"`
+    Downloaded string `xml:"downloaded"`
+    Current string `xml:"current"`
+    Previous string `xml:"previous"`
+    Installing string `xml:"installing"`
+}
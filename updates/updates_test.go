@@ -0,0 +1,153 @@
+package updates
+
+import (
+    "context"
+    "fmt"
+    "testing"
+    "time"
+
+    "github.com/inwinstack/pango/testdata"
+)
+
+
+func TestCheckSoftware(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp(`<sw-updates><versions>
+        <entry><version>9.1.0</version><filename>PanOS_vm-9.1.0</filename><size>400</size><released-on>2019/12/01</released-on><downloaded>yes</downloaded><current>no</current><latest>yes</latest><uploaded>no</uploaded></entry>
+        <entry><version>9.0.0</version><downloaded>no</downloaded><current>yes</current><latest>no</latest></entry>
+    </versions></sw-updates>`)
+    c := &Updates{}
+    c.Initialize(mc)
+
+    list, err := c.CheckSoftware()
+    if err != nil {
+        t.Fatalf("Error in check: %s", err)
+    }
+    if mc.Elm != "<request><system><software><check></check></software></system></request>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+    if len(list) != 2 {
+        t.Fatalf("Expected 2 versions, got %d", len(list))
+    }
+    if v := list[0]; v.Version != "9.1.0" || !v.Downloaded || v.Current || !v.Latest || v.Filename != "PanOS_vm-9.1.0" {
+        t.Errorf("Unexpected version: %#v", v)
+    }
+    if v := list[1]; v.Version != "9.0.0" || v.Downloaded || !v.Current {
+        t.Errorf("Unexpected version: %#v", v)
+    }
+}
+
+func TestSoftwareJobs(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp("<job>12</job>")
+    c := &Updates{}
+    c.Initialize(mc)
+
+    testCases := []struct{
+        desc string
+        fn func(string, bool) (uint, error)
+        elm string
+    }{
+        {"download", c.DownloadSoftware, "<request><system><software><download><version>9.1.0</version></download></software></system></request>"},
+        {"install", c.InstallSoftware, "<request><system><software><install><version>9.1.0</version></install></software></system></request>"},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            mc.Reset()
+            job, err := tc.fn("9.1.0", false)
+            if err != nil {
+                t.Fatalf("Error: %s", err)
+            } else if job != 12 {
+                t.Errorf("Expected job 12, got %d", job)
+            } else if mc.Elm != tc.elm {
+                t.Errorf("Expected %q, got %q", tc.elm, mc.Elm)
+            }
+        })
+    }
+}
+
+func TestContent(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp(`<content-updates><entry><version>8200-5820</version><app-version>8200-5820</app-version><downloaded>yes</downloaded><current>no</current><previous>no</previous><installing>no</installing></entry></content-updates>`)
+    c := &Updates{}
+    c.Initialize(mc)
+
+    for _, kind := range []string{Content, Antivirus, Wildfire} {
+        t.Run(kind, func(t *testing.T) {
+            list, err := c.CheckContent(kind)
+            if err != nil {
+                t.Fatalf("Error in check: %s", err)
+            }
+            if mc.Elm != fmt.Sprintf("<request><%s><upgrade><check></check></upgrade></%s></request>", kind, kind) {
+                t.Errorf("Unexpected command: %s", mc.Elm)
+            }
+            if len(list) != 1 || list[0].Version != "8200-5820" || !list[0].Downloaded {
+                t.Errorf("Unexpected versions: %#v", list)
+            }
+        })
+    }
+
+    mc.Resp = nil
+    mc.AddResp("<job>4</job>")
+    if _, err := c.DownloadContent(Antivirus, false); err != nil {
+        t.Errorf("Error in download: %s", err)
+    } else if mc.Elm != "<request><anti-virus><upgrade><download><latest></latest></download></upgrade></anti-virus></request>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+
+    if job, err := c.InstallContent(Content, "", true); err != nil {
+        t.Errorf("Error in install: %s", err)
+    } else if job != 4 {
+        t.Errorf("Expected job 4, got %d", job)
+    } else if mc.Elm != "<request><content><upgrade><install><version>latest</version></install></upgrade></content></request>" {
+        t.Errorf("Unexpected command: %s", mc.Elm)
+    }
+}
+
+func TestRebootWait(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.Resp = []testdata.Response{
+        {[]byte("<response><result>Restarting</result></response>"), nil},
+        {[]byte("<response><result/></response>"), nil},
+        {nil, fmt.Errorf("connection refused")},
+        {nil, fmt.Errorf("connection refused")},
+        {[]byte("<response><result><system/></result></response>"), nil},
+    }
+    c := &Updates{RebootPollInterval: time.Millisecond, RebootTimeout: time.Second}
+    c.Initialize(mc)
+
+    if err := c.Reboot(true); err != nil {
+        t.Errorf("Error in reboot: %s", err)
+    } else if mc.Called != 5 {
+        t.Errorf("Expected 5 calls, got %d", mc.Called)
+    }
+}
+
+func TestRebootTimeout(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp("")
+    c := &Updates{RebootPollInterval: time.Millisecond, RebootTimeout: 10 * time.Millisecond}
+    c.Initialize(mc)
+
+    if err := c.Reboot(true); err == nil {
+        t.Errorf("Expected an error for a device that never went down")
+    }
+}
+
+func TestRebootWaitCanceled(t *testing.T) {
+    mc := &testdata.MockClient{}
+    mc.AddResp("")
+    c := &Updates{RebootPollInterval: time.Millisecond, RebootTimeout: time.Minute}
+    c.Initialize(mc)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 20 * time.Millisecond)
+    defer cancel()
+
+    start := time.Now()
+    if err := c.WaitForRebootContext(ctx); err != context.DeadlineExceeded {
+        t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+    } else if time.Since(start) > 10 * time.Second {
+        t.Errorf("Wait was not stopped by the context")
+    }
+}
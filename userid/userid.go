@@ -1,6 +1,6 @@
 // Package userid is the client.UserId namespace, for interacting with the
 // User-ID API.  This includes login/logout of a user, user/group mappings,
-// and dynamic address group tags.
+// dynamic address group tags, and dynamic user group tags.
 //
 // Various features of User-ID API are supported across all versions of PANOS
 // for the firewall, but User-ID API for Panorama was only added to PANOS
@@ -26,6 +26,26 @@ func (c *UserId) Initialize(i util.XapiClient) {
     c.con = i
 }
 
+// Message is a set of User-Id operations to be sent in a single uid-message.
+//
+// Both Logins and Logouts are maps where the username is the key and the IP
+// address is the value.
+//
+// Both Register and Unregister are maps where the IP address is the key and
+// the list of tags are the values.
+//
+// Both RegisterUser and UnregisterUser are maps where the username is the key
+// and the list of tags are the values.  Tagging users is how users are placed
+// into dynamic user groups, and is PAN-OS 9.1+.
+type Message struct {
+    Logins map[string] string
+    Logouts map[string] string
+    Register map[string] []string
+    Unregister map[string] []string
+    RegisterUser map[string] []string
+    UnregisterUser map[string] []string
+}
+
 // Run executes the given User-Id related operations.  This allows you to
 // perform the following User-Id operations:
 //
@@ -42,64 +62,37 @@ func (c *UserId) Initialize(i util.XapiClient) {
 //
 // The vsys param is which vsys these operations should take place in.  If
 // vsys is an empty string, vsys defaults to "vsys1".
+//
+// To also register or unregister user tags, use RunMessage.
 func (c *UserId) Run(logins, logouts map[string] string, reg, unreg map[string] []string, vsys string) error {
-    var i int
+    return c.RunMessage(Message{
+        Logins: logins,
+        Logouts: logouts,
+        Register: reg,
+        Unregister: unreg,
+    }, vsys)
+}
+
+// RunMessage executes all the User-Id operations in the given message with a
+// single uid-message.
+//
+// If the message registers or unregisters user tags, then the uid-message is
+// sent as version 2.0, which requires PAN-OS 9.1+.
+//
+// The vsys param is which vsys these operations should take place in.  If
+// vsys is an empty string, vsys defaults to "vsys1".
+func (c *UserId) RunMessage(m Message, vsys string) error {
     if vsys == "" {
         vsys = "vsys1"
     }
-    c.con.LogUid("(userid) running in %s - logins:%d logouts:%d reg:%d unreg:%d", vsys, len(logins), len(logouts), len(reg), len(unreg))
+    c.con.LogUid("(userid) running in %s - logins:%d logouts:%d reg:%d unreg:%d user reg:%d user unreg:%d", vsys, len(m.Logins), len(m.Logouts), len(m.Register), len(m.Unregister), len(m.RegisterUser), len(m.UnregisterUser))
 
-    msg := uid{Version: "1.0", Type: "update"}
-
-    if len(logins) == 0 && len(logouts) == 0 && len(reg) == 0 && len(unreg) == 0 {
-        return nil
+    msg, err := c.build(m)
+    if err != nil || msg == nil {
+        return err
     }
 
-    // Login users.
-    if len(logins) > 0 {
-        i = 0
-        msg.Payload.Login = &inOutCon{}
-        msg.Payload.Login.Entry = make([]inOut, len(logins))
-        for k, v := range logins {
-            msg.Payload.Login.Entry[i] = inOut{Name: k, Ip: v}
-            i++
-        }
-    }
-
-    // Logout users.
-    if len(logouts) > 0 {
-        i = 0
-        msg.Payload.Logout = &inOutCon{}
-        msg.Payload.Logout.Entry = make([]inOut, len(logouts))
-        for k, v := range logouts {
-            msg.Payload.Logout.Entry[i] = inOut{Name: k, Ip: v}
-            i++
-        }
-    }
-
-    // Register ip/tags.
-    if len(reg) > 0 {
-        i = 0
-        msg.Payload.Register = &regUnregCon{}
-        msg.Payload.Register.Entry = make([]regUnreg, len(reg))
-        for ip, tags := range reg {
-            msg.Payload.Register.Entry[i] = regUnreg{Ip: ip, Tag: tags}
-            i++
-        }
-    }
-
-    // Unregister ip/tags.
-    if len(unreg) > 0 {
-        i = 0
-        msg.Payload.Unregister = &regUnregCon{}
-        msg.Payload.Unregister.Entry = make([]regUnreg, len(unreg))
-        for ip, tags := range unreg {
-            msg.Payload.Unregister.Entry[i] = regUnreg{Ip: ip, Tag: tags}
-            i++
-        }
-    }
-
-    _, err := c.con.Uid(msg, vsys, nil, nil)
+    _, err = c.con.Uid(msg, vsys, nil, nil)
     return err
 }
 
@@ -142,6 +135,94 @@ func (c *UserId) Registered(ip, tag, vsys string) (map[string] []string, error)
 
 /** Internal functions for the UserId struct **/
 
+// build returns the uid-message for the given operations, or nil if there
+// are no operations to perform.
+func (c *UserId) build(m Message) (*uid, error) {
+    var i int
+
+    if len(m.Logins) == 0 && len(m.Logouts) == 0 && len(m.Register) == 0 && len(m.Unregister) == 0 && len(m.RegisterUser) == 0 && len(m.UnregisterUser) == 0 {
+        return nil, nil
+    }
+
+    msg := &uid{Version: "1.0", Type: "update"}
+
+    // User tags are only in the 2.0 payload format.
+    if len(m.RegisterUser) > 0 || len(m.UnregisterUser) > 0 {
+        if !c.con.Versioning().Gte(version.Number{9, 1, 0, ""}) {
+            return nil, fmt.Errorf("User tags are only supported on PAN-OS 9.1+")
+        }
+        msg.Version = "2.0"
+    }
+
+    // Login users.
+    if len(m.Logins) > 0 {
+        i = 0
+        msg.Payload.Login = &inOutCon{}
+        msg.Payload.Login.Entry = make([]inOut, len(m.Logins))
+        for k, v := range m.Logins {
+            msg.Payload.Login.Entry[i] = inOut{Name: k, Ip: v}
+            i++
+        }
+    }
+
+    // Logout users.
+    if len(m.Logouts) > 0 {
+        i = 0
+        msg.Payload.Logout = &inOutCon{}
+        msg.Payload.Logout.Entry = make([]inOut, len(m.Logouts))
+        for k, v := range m.Logouts {
+            msg.Payload.Logout.Entry[i] = inOut{Name: k, Ip: v}
+            i++
+        }
+    }
+
+    // Register ip/tags.
+    if len(m.Register) > 0 {
+        i = 0
+        msg.Payload.Register = &regUnregCon{}
+        msg.Payload.Register.Entry = make([]regUnreg, len(m.Register))
+        for ip, tags := range m.Register {
+            msg.Payload.Register.Entry[i] = regUnreg{Ip: ip, Tag: tags}
+            i++
+        }
+    }
+
+    // Unregister ip/tags.
+    if len(m.Unregister) > 0 {
+        i = 0
+        msg.Payload.Unregister = &regUnregCon{}
+        msg.Payload.Unregister.Entry = make([]regUnreg, len(m.Unregister))
+        for ip, tags := range m.Unregister {
+            msg.Payload.Unregister.Entry[i] = regUnreg{Ip: ip, Tag: tags}
+            i++
+        }
+    }
+
+    // Register user/tags.
+    if len(m.RegisterUser) > 0 {
+        i = 0
+        msg.Payload.RegisterUser = &userRegUnregCon{}
+        msg.Payload.RegisterUser.Entry = make([]userRegUnreg, len(m.RegisterUser))
+        for user, tags := range m.RegisterUser {
+            msg.Payload.RegisterUser.Entry[i] = userRegUnreg{User: user, Tag: tags}
+            i++
+        }
+    }
+
+    // Unregister user/tags.
+    if len(m.UnregisterUser) > 0 {
+        i = 0
+        msg.Payload.UnregisterUser = &userRegUnregCon{}
+        msg.Payload.UnregisterUser.Entry = make([]userRegUnreg, len(m.UnregisterUser))
+        for user, tags := range m.UnregisterUser {
+            msg.Payload.UnregisterUser.Entry[i] = userRegUnreg{User: user, Tag: tags}
+            i++
+        }
+    }
+
+    return msg, nil
+}
+
 func (c *UserId) versioning() filterer {
     v := c.con.Versioning()
 
@@ -168,6 +249,8 @@ type payload struct {
     Logout *inOutCon `xml:"logout"`
     Register *regUnregCon `xml:"register"`
     Unregister *regUnregCon `xml:"unregister"`
+    RegisterUser *userRegUnregCon `xml:"register-user"`
+    UnregisterUser *userRegUnregCon `xml:"unregister-user"`
 }
 
 type inOutCon struct {
@@ -190,6 +273,16 @@ type regUnreg struct {
     Tag []string `xml:"tag>member"`
 }
 
+type userRegUnregCon struct {
+    Entry []userRegUnreg `xml:"entry"`
+}
+
+type userRegUnreg struct {
+    XMLName xml.Name `xml:"entry"`
+    User string `xml:"user,attr"`
+    Tag []string `xml:"tag>member"`
+}
+
 type filterer interface {
     FilterOn(string, string, int)
     ShouldStop(int) bool
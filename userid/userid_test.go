@@ -48,6 +48,111 @@ func TestRun(t *testing.T) {
     }
 }
 
+func TestRunMessageUserTags(t *testing.T) {
+    testCases := []struct{
+        reg, unreg map[string] []string
+        vsys, desc, elm string
+    }{
+        {map[string] []string{"john": []string{"one"}}, nil, "", "register and empty vsys", "<register-user><entry user=\"john\"><tag><member>one</member></tag></entry></register-user>"},
+        {nil, map[string] []string{"jack": []string{"two", "three"}}, "vsys2", "unregister and vsys2", "<unregister-user><entry user=\"jack\"><tag><member>two</member><member>three</member></tag></entry></unregister-user>"},
+    }
+    mc := &testdata.MockClient{
+        Version: version.Number{9, 1, 0, ""},
+        Resp: []testdata.Response{
+            testdata.Response{[]byte(""), nil},
+            testdata.Response{[]byte(""), nil},
+        },
+    }
+    u := &UserId{}
+    u.Initialize(mc)
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            i := mc.Called
+            err := u.RunMessage(Message{RegisterUser: tc.reg, UnregisterUser: tc.unreg}, tc.vsys)
+            if err != nil || mc.Called == i {
+                t.Errorf("Failed basic checks")
+            } else {
+                if tc.vsys == "" {
+                    if mc.Vsys != "vsys1" {
+                        t.Errorf("Vsys is %s, not vsys1", mc.Vsys)
+                    }
+                } else if mc.Vsys != tc.vsys {
+                    t.Errorf("Vsys is %s, not %s", mc.Vsys, tc.vsys)
+                }
+                if strings.Index(mc.Elm, "<version>2.0</version>") == -1 {
+                    t.Errorf("Elm %q is not version 2.0", mc.Elm)
+                }
+                if strings.Index(mc.Elm, tc.elm) == -1 {
+                    t.Errorf("Elm %q does not contain %q", mc.Elm, tc.elm)
+                }
+            }
+        })
+    }
+}
+
+func TestRunMessageCombined(t *testing.T) {
+    mc := &testdata.MockClient{
+        Version: version.Number{9, 1, 0, ""},
+        Resp: []testdata.Response{
+            testdata.Response{[]byte(""), nil},
+        },
+    }
+    u := &UserId{}
+    u.Initialize(mc)
+
+    err := u.RunMessage(Message{
+        Logins: map[string] string{"john": "1.2.3.4"},
+        Register: map[string] []string{"1.2.3.4": []string{"one"}},
+        RegisterUser: map[string] []string{"john": []string{"two"}},
+    }, "")
+    if err != nil {
+        t.Fatalf("Error in run: %s", err)
+    } else if mc.Called != 1 {
+        t.Fatalf("Expected 1 call, got %d", mc.Called)
+    }
+
+    for _, s := range []string{
+        "<version>2.0</version>",
+        "<login><entry name=\"john\" ip=\"1.2.3.4\"></entry></login>",
+        "<register><entry ip=\"1.2.3.4\"><tag><member>one</member></tag></entry></register>",
+        "<register-user><entry user=\"john\"><tag><member>two</member></tag></entry></register-user>",
+    } {
+        if strings.Index(mc.Elm, s) == -1 {
+            t.Errorf("Elm %q does not contain %q", mc.Elm, s)
+        }
+    }
+}
+
+func TestRunVersion(t *testing.T) {
+    mc := &testdata.MockClient{
+        Version: version.Number{9, 1, 0, ""},
+        Resp: []testdata.Response{
+            testdata.Response{[]byte(""), nil},
+        },
+    }
+    u := &UserId{}
+    u.Initialize(mc)
+
+    if err := u.Run(map[string] string{"john": "1.2.3.4"}, nil, nil, nil, ""); err != nil {
+        t.Fatalf("Error in run: %s", err)
+    }
+    if strings.Index(mc.Elm, "<version>1.0</version>") == -1 {
+        t.Errorf("Elm %q is not version 1.0", mc.Elm)
+    }
+}
+
+func TestRunMessageUserTagsUnsupported(t *testing.T) {
+    mc := &testdata.MockClient{Version: version.Number{9, 0, 0, ""}}
+    u := &UserId{}
+    u.Initialize(mc)
+
+    err := u.RunMessage(Message{RegisterUser: map[string] []string{"john": []string{"one"}}}, "")
+    if err == nil || mc.Called != 0 {
+        t.Errorf("Expected an error and no API call on PAN-OS 9.0")
+    }
+}
+
 func TestRegistered(t *testing.T) {
     testCases := []struct{
         n version.Number
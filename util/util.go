@@ -4,9 +4,13 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/inwinstack/pango/version"
@@ -31,6 +35,14 @@ const (
 	VlanImport          = "vlan"
 )
 
+// ErrUnsupportedVersion is returned when the PAN-OS version of the device
+// does not support the requested config or operation.
+var ErrUnsupportedVersion = errors.New("unsupported by this PAN-OS version")
+
+// ErrObjectNotFound is matched by the error returned when the config object
+// does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
 // XapiClient is the interface that describes an pango.Client.
 type XapiClient interface {
 	String() string
@@ -47,12 +59,24 @@ type XapiClient interface {
 	Edit(interface{}, interface{}, interface{}, interface{}) ([]byte, error)
 	Move(interface{}, string, string, interface{}, interface{}) ([]byte, error)
 	Uid(interface{}, string, interface{}, interface{}) ([]byte, error)
+	Communicate(url.Values, interface{}) ([]byte, error)
+	Import(string, string, string, string, map[string]string, interface{}) ([]byte, error)
+	OpContext(context.Context, interface{}, string, interface{}, interface{}) ([]byte, error)
+	ShowContext(context.Context, interface{}, interface{}, interface{}) ([]byte, error)
+	GetContext(context.Context, interface{}, interface{}, interface{}) ([]byte, error)
+	DeleteContext(context.Context, interface{}, interface{}, interface{}) ([]byte, error)
+	SetContext(context.Context, interface{}, interface{}, interface{}, interface{}) ([]byte, error)
+	EditContext(context.Context, interface{}, interface{}, interface{}, interface{}) ([]byte, error)
+	MoveContext(context.Context, interface{}, string, string, interface{}, interface{}) ([]byte, error)
+	UidContext(context.Context, interface{}, string, interface{}, interface{}) ([]byte, error)
+	CommunicateContext(context.Context, url.Values, interface{}) ([]byte, error)
 	EntryListUsing(Retriever, []string) ([]string, error)
 	MemberListUsing(Retriever, []string) ([]string, error)
 	RequestPasswordHash(string) (string, error)
 	VsysImport(string, string, string, string, []string) error
 	VsysUnimport(string, string, string, []string) error
 	WaitForJob(uint, interface{}) error
+	WaitForJobContext(context.Context, uint, interface{}) error
 	Commit(string, []string, bool, bool, bool, bool) (uint, error)
 	PositionFirstEntity(int, string, string, []string, []string) error
 	GetHighAvailabilityStatus() (*HighAvailability, error)
@@ -423,15 +447,75 @@ type JobResponse struct {
 // BasicJob is a struct for parsing minimal information about a submitted
 // job to PANOS.
 type BasicJob struct {
-	XMLName  xml.Name `xml:"response"`
-	Result   string   `xml:"result>job>result"`
-	Progress uint     `xml:"result>job>progress"`
-	Details  []string `xml:"result>job>details>line"`
-	Devices  []devJob `xml:"result>job>devices>entry"`
+	XMLName xml.Name `xml:"response"`
+	Job     `xml:"result>job"`
+}
+
+// UnmarshalXML unmarshals the job in the response, since the promoted
+// Job.UnmarshalXML would otherwise be used for the entire response.
+func (o *BasicJob) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var ans struct {
+		Job Job `xml:"result>job"`
+	}
+	if err := d.DecodeElement(&ans, &start); err != nil {
+		return err
+	}
+
+	o.XMLName = start.Name
+	o.Job = ans.Job
+	return nil
+}
+
+// Job is a job on PAN-OS, as returned from "show jobs".
+//
+// Status is one of "ACT", "PEND", or "FIN", while Result is one of "OK",
+// "FAIL", or "PEND".  The timestamps are in the device's local time, in the
+// format "2006/01/02 15:04:05".
+type Job struct {
+	Id          uint        `xml:"id"`
+	Type        string      `xml:"type"`
+	Status      string      `xml:"status"`
+	Result      string      `xml:"result"`
+	Progress    uint        `xml:"progress"`
+	User        string      `xml:"user"`
+	Description string      `xml:"description"`
+	Enqueued    string      `xml:"tenq"`
+	Dequeued    string      `xml:"tdeq"`
+	Finished    string      `xml:"tfin"`
+	Details     []string    `xml:"details>line"`
+	Warnings    []string    `xml:"warnings>line"`
+	Devices     []DeviceJob `xml:"devices>entry"`
+}
+
+// Done returns true if the job has finished.
+func (o Job) Done() bool {
+	return o.Status == "FIN"
+}
+
+// UnmarshalXML handles finished jobs whose progress is reported as the
+// finish timestamp instead of a percentage.
+func (o *Job) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type local Job
+	var ans struct {
+		local
+		Progress string `xml:"progress"`
+	}
+	if err := d.DecodeElement(&ans, &start); err != nil {
+		return err
+	}
+
+	*o = Job(ans.local)
+	if v, err := strconv.ParseUint(strings.TrimSpace(ans.Progress), 10, 0); err == nil {
+		o.Progress = uint(v)
+	} else if o.Done() {
+		o.Progress = 100
+	}
+
+	return nil
 }
 
-// Internally used by BasicJob for panorama commit-all.
-type devJob struct {
+// DeviceJob is the per device result of a job, such as a Panorama commit-all.
+type DeviceJob struct {
 	Serial string `xml:"serial-no"`
 	Result string `xml:"result"`
 }
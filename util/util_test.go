@@ -1,6 +1,7 @@
 package util
 
 import (
+    "encoding/xml"
     "fmt"
     "testing"
 )
@@ -191,3 +192,31 @@ func TestCleanRawXml(t *testing.T) {
         t.Fail()
     }
 }
+
+func TestBasicJobUnmarshal(t *testing.T) {
+    testCases := []struct{
+        desc string
+        progress string
+        status string
+        expected uint
+    }{
+        {"running", "45", "ACT", 45},
+        {"finished", "100", "FIN", 100},
+        {"finished timestamp", "2020/01/02 03:04:05", "FIN", 100},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.desc, func(t *testing.T) {
+            data := fmt.Sprintf("<response><result><job><id>4</id><status>%s</status><result>OK</result><progress>%s</progress><details><line>a</line></details></job></result></response>", tc.status, tc.progress)
+            var ans BasicJob
+            if err := xml.Unmarshal([]byte(data), &ans); err != nil {
+                t.Fatalf("Error in unmarshal: %s", err)
+            }
+            if ans.Id != 4 || ans.Result != "OK" || len(ans.Details) != 1 {
+                t.Errorf("Unexpected job: %#v", ans)
+            } else if ans.Progress != tc.expected {
+                t.Errorf("Expected progress %d, got %d", tc.expected, ans.Progress)
+            }
+        })
+    }
+}